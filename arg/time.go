@@ -0,0 +1,42 @@
+package arg
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+var relativePattern = regexp.MustCompile(`^(\d+)([dwy])$`)
+
+var dateLayouts = []string{time.RFC3339, "2006-01-02T15:04", "2006-01-02", "2006-01", "2006"}
+
+// ParseTime parses either an absolute date such as 2025-01-01 or 2025-01,
+// or an age relative to now such as 30d, 2w, 1y or 12h, which is
+// subtracted from now.
+func ParseTime(s string, now time.Time) (time.Time, error) {
+	if match := relativePattern.FindStringSubmatch(s); match != nil {
+		n, err := strconv.Atoi(match[1])
+		if err != nil {
+			return time.Time{}, err
+		}
+		switch match[2] {
+		case "d":
+			return now.AddDate(0, 0, -n), nil
+		case "w":
+			return now.AddDate(0, 0, -7*n), nil
+		default:
+			return now.AddDate(-n, 0, 0), nil
+		}
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		return now.Add(-d), nil
+	}
+	for _, layout := range dateLayouts {
+		if t, err := time.ParseInLocation(layout, s, now.Location()); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, errors.New(fmt.Sprintf("cannot parse \"%v\" as a date or age, e.g. 2025-01-31 or 30d", s))
+}
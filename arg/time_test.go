@@ -0,0 +1,38 @@
+package arg
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTime(t *testing.T) {
+	now := time.Date(2025, 3, 15, 12, 0, 0, 0, time.UTC)
+	cases := []struct {
+		s        string
+		expected time.Time
+	}{
+		{"30d", time.Date(2025, 2, 13, 12, 0, 0, 0, time.UTC)},
+		{"2w", time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)},
+		{"1y", time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)},
+		{"12h", time.Date(2025, 3, 15, 0, 0, 0, 0, time.UTC)},
+		{"2025-01-01", time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"2025-01", time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"2024", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"2025-01-02T03:04:05Z", time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)},
+	}
+	for _, c := range cases {
+		actual, err := ParseTime(c.s, now)
+		if err != nil {
+			t.Fatalf("%v: %v", c.s, err.Error())
+		}
+		if !actual.Equal(c.expected) {
+			t.Fatalf("%v: expected %v, received %v", c.s, c.expected, actual)
+		}
+	}
+}
+
+func TestParseTimeFail(t *testing.T) {
+	if _, err := ParseTime("last tuesday", time.Now()); err == nil {
+		t.Fatal("should return error")
+	}
+}
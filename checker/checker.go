@@ -0,0 +1,78 @@
+package checker
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/tomguerney/marks/marks"
+)
+
+type checker struct {
+	client      *http.Client
+	concurrency int
+}
+
+func NewChecker(timeout time.Duration, concurrency int) *checker {
+	return &checker{
+		client:      &http.Client{Timeout: timeout},
+		concurrency: concurrency,
+	}
+}
+
+// Check requests the url of each mark using a pool of workers and returns
+// the statuses in the same order as mks.
+func (c *checker) Check(mks []*marks.Mark) []*marks.LinkStatus {
+	statuses := make([]*marks.LinkStatus, len(mks))
+	jobs := make(chan int)
+	wg := sync.WaitGroup{}
+	workers := c.concurrency
+	if workers < 1 {
+		workers = 1
+	}
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				statuses[i] = c.check(mks[i])
+			}
+		}()
+	}
+	for i := range mks {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	return statuses
+}
+
+// check tries a HEAD request first, falling back to GET for servers that
+// refuse or mishandle HEAD.
+func (c *checker) check(m *marks.Mark) *marks.LinkStatus {
+	resp, err := c.request(http.MethodHead, m.Url)
+	if err != nil || resp.StatusCode >= 400 {
+		resp, err = c.request(http.MethodGet, m.Url)
+	}
+	if err != nil {
+		return &marks.LinkStatus{Mark: m, Err: err}
+	}
+	return &marks.LinkStatus{
+		Mark:       m,
+		StatusCode: resp.StatusCode,
+		Location:   resp.Request.URL.String(),
+	}
+}
+
+func (c *checker) request(method, url string) (*http.Response, error) {
+	req, err := http.NewRequest(method, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	return resp, nil
+}
@@ -0,0 +1,80 @@
+package checker
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/tomguerney/marks/marks"
+)
+
+func newTestServer() *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("/missing", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	mux.HandleFunc("/moved", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/ok", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/get-only", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	})
+	return httptest.NewServer(mux)
+}
+
+func TestCheck(t *testing.T) {
+	server := newTestServer()
+	defer server.Close()
+	mks := []*marks.Mark{
+		&marks.Mark{Id: "ok", Url: server.URL + "/ok"},
+		&marks.Mark{Id: "missing", Url: server.URL + "/missing"},
+		&marks.Mark{Id: "moved", Url: server.URL + "/moved"},
+		&marks.Mark{Id: "get only", Url: server.URL + "/get-only"},
+		&marks.Mark{Id: "slow", Url: server.URL + "/slow"},
+		&marks.Mark{Id: "invalid", Url: "not a url"},
+	}
+	statuses := NewChecker(100*time.Millisecond, 3).Check(mks)
+	if len(statuses) != len(mks) {
+		t.Fatalf("expected %v statuses, received %v", len(mks), len(statuses))
+	}
+	for i, status := range statuses {
+		if status.Mark != mks[i] {
+			t.Fatalf("expected status %v for mark %v, received %v", i, mks[i].Id, status.Mark.Id)
+		}
+	}
+	expectedCodes := []int{200, 404, 200, 200, 0, 0}
+	expectedBroken := []bool{false, true, false, false, true, true}
+	for i, status := range statuses {
+		if status.StatusCode != expectedCodes[i] {
+			t.Errorf("%v: expected status code %v, received %v", mks[i].Id, expectedCodes[i], status.StatusCode)
+		}
+		if status.Broken() != expectedBroken[i] {
+			t.Errorf("%v: expected broken %v, received %v", mks[i].Id, expectedBroken[i], status.Broken())
+		}
+	}
+	if !statuses[2].Redirected() || statuses[2].Location != server.URL+"/ok" {
+		t.Errorf("expected redirect to %v, received %v", server.URL+"/ok", statuses[2].Location)
+	}
+	if statuses[0].Redirected() {
+		t.Errorf("expected no redirect, received %v", statuses[0].Location)
+	}
+}
+
+func TestCheckNoMarks(t *testing.T) {
+	statuses := NewChecker(time.Second, 0).Check([]*marks.Mark{})
+	if len(statuses) != 0 {
+		t.Fatalf("expected zero length slice, received %v", len(statuses))
+	}
+}
@@ -16,11 +16,13 @@ limitations under the License.
 package cmd
 
 import (
+	"time"
+
 	"github.com/tomguerney/marks/arg"
-	"github.com/tomguerney/marks/colorizer"
 	"github.com/tomguerney/marks/config"
+	"github.com/tomguerney/marks/fetcher"
 	"github.com/tomguerney/marks/io"
-	"github.com/tomguerney/marks/printer"
+	"github.com/tomguerney/marks/prompter"
 	"github.com/tomguerney/marks/runner"
 	"github.com/tomguerney/marks/yaml"
 	"github.com/spf13/cobra"
@@ -30,9 +32,9 @@ import (
 
 // addCmd represents the add command
 var addCmd = &cobra.Command{
-	Use:   "add id",
+	Use:   "add [id]",
 	Short: "Add a bookmark",
-	Args:  cobra.ExactArgs(1),
+	Args:  cobra.MaximumNArgs(1),
 	RunE:  runAdd,
 }
 
@@ -46,9 +48,17 @@ func runAdd(cmd *cobra.Command, argv []string) error {
 		return err
 	}
 	markService := yaml.NewMarkService(config, io.NewReaderWriter())
-	colorizer := colorizer.NewColorizer()
-	printer := printer.NewPrinter(config, colorizer)
-	runner := runner.NewAddRunner(args, config, markService, printer)
+	printer, err := newPrinter(config)
+	if err != nil {
+		return err
+	}
+	timeout, err := cmd.Flags().GetDuration("timeout")
+	if err != nil {
+		return err
+	}
+	prompter := prompter.NewPrompter()
+	fetcher := fetcher.NewFetcher(timeout)
+	runner := runner.NewAddRunner(args, config, markService, printer, prompter, fetcher)
 	if err := runner.Run(); err != nil {
 		return err
 	}
@@ -60,15 +70,25 @@ func init() {
 	addCmd.Flags().StringP("url", "u", "", "--url https://www.abc.net.au/news/")
 	addCmd.MarkFlagRequired("url")
 	addCmd.Flags().StringSliceP("tag", "t", []string{}, "--tag news --tag \"current affairs\"")
+	addCmd.Flags().String("note", "", "--note \"Saved for the election coverage\"")
+	addCmd.Flags().String("browser", "", "always open in this browser, any listed by marks browsers --browser firefox")
+	addCmd.Flags().Bool("strict", false, "refuse to add a url that is already bookmarked")
+	addCmd.Flags().Bool("fetch", false, "propose the id and suggest tags from the page, and take its description")
+	addCmd.Flags().Bool("slug", false, "with --fetch, turn the page title into a slug for the id")
+	addCmd.Flags().Duration("timeout", 10*time.Second, "--timeout 5s")
 }
 
 func combineAddArgs(flagSet *pflag.FlagSet, argv []string) (*runner.AddArgs, error) {
 
 	parser := arg.NewParser(argv)
 
-	id, err := parser.Pop()
-	if err != nil {
-		return nil, err
+	var id string
+	if len(parser.Remaining()) > 0 {
+		popped, err := parser.Pop()
+		if err != nil {
+			return nil, err
+		}
+		id = popped
 	}
 
 	url, err := flagSet.GetString("url")
@@ -81,5 +101,30 @@ func combineAddArgs(flagSet *pflag.FlagSet, argv []string) (*runner.AddArgs, err
 		return nil, err
 	}
 
-	return runner.NewAddArgs(id, url, tags), nil
+	note, err := flagSet.GetString("note")
+	if err != nil {
+		return nil, err
+	}
+
+	browser, err := flagSet.GetString("browser")
+	if err != nil {
+		return nil, err
+	}
+
+	strict, err := flagSet.GetBool("strict")
+	if err != nil {
+		return nil, err
+	}
+
+	fetch, err := flagSet.GetBool("fetch")
+	if err != nil {
+		return nil, err
+	}
+
+	slug, err := flagSet.GetBool("slug")
+	if err != nil {
+		return nil, err
+	}
+
+	return runner.NewAddArgs(id, url, tags, note, browser, strict, fetch, slug), nil
 }
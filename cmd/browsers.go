@@ -0,0 +1,51 @@
+/*
+Copyright © 2020 NAME HERE <EMAIL ADDRESS>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"github.com/tomguerney/marks/config"
+	"github.com/tomguerney/marks/runner"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// browsersCmd represents the browsers command
+var browsersCmd = &cobra.Command{
+	Use:   "browsers",
+	Short: "List the browsers bookmarks can be opened in",
+	Args:  cobra.NoArgs,
+	RunE:  runBrowsers,
+}
+
+func runBrowsers(cmd *cobra.Command, argv []string) error {
+	config, err := config.NewLoader(viper.GetViper()).Load()
+	if err != nil {
+		return err
+	}
+	printer, err := newPrinter(config)
+	if err != nil {
+		return err
+	}
+	runner := runner.NewListBrowsersRunner(config, printer)
+	if err := runner.Run(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(browsersCmd)
+}
@@ -0,0 +1,111 @@
+/*
+Copyright © 2020 NAME HERE <EMAIL ADDRESS>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"time"
+
+	"github.com/tomguerney/marks/arg"
+	"github.com/tomguerney/marks/checker"
+	"github.com/tomguerney/marks/config"
+	"github.com/tomguerney/marks/io"
+	"github.com/tomguerney/marks/runner"
+	"github.com/tomguerney/marks/yaml"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+// checkCmd represents the check command
+var checkCmd = &cobra.Command{
+	Use:   "check [id] [tags...]",
+	Short: "Check bookmarks for broken links",
+	RunE:  runCheck,
+}
+
+func runCheck(cmd *cobra.Command, argv []string) error {
+	args, err := combineCheckArgs(cmd.Flags(), argv)
+	if err != nil {
+		return err
+	}
+	timeout, err := cmd.Flags().GetDuration("timeout")
+	if err != nil {
+		return err
+	}
+	concurrency, err := cmd.Flags().GetInt("concurrency")
+	if err != nil {
+		return err
+	}
+	config, err := config.NewLoader(viper.GetViper()).Load()
+	if err != nil {
+		return err
+	}
+	markService := yaml.NewMarkService(config, io.NewReaderWriter())
+	printer, err := newPrinter(config)
+	if err != nil {
+		return err
+	}
+	checker := checker.NewChecker(timeout, concurrency)
+	runner := runner.NewCheckRunner(args, config, markService, printer, checker)
+	if err := runner.Run(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(checkCmd)
+	checkCmd.Flags().StringP("url", "u", "", "(can be partial) --url abc.net.au")
+	checkCmd.Flags().StringSliceP("tag", "t", []string{}, "--tag news --tag \"current affairs\"")
+	checkCmd.Flags().Duration("timeout", 10*time.Second, "--timeout 5s")
+	checkCmd.Flags().IntP("concurrency", "c", 8, "--concurrency 16")
+	checkCmd.Flags().Bool("tag-broken", false, "tag broken bookmarks with \"broken\"")
+}
+
+func combineCheckArgs(flagSet *pflag.FlagSet, argv []string) (*runner.CheckArgs, error) {
+
+	parser := arg.NewParser(argv)
+
+	var id string
+	if len(parser.Remaining()) > 0 {
+		popped, err := parser.Pop()
+		if err != nil {
+			return nil, err
+		}
+		id = popped
+	}
+
+	flagTags := parser.Remaining()
+
+	url, err := flagSet.GetString("url")
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := flagSet.GetStringSlice("tag")
+	if err != nil {
+		return nil, err
+	}
+
+	tags = append(tags, flagTags...)
+
+	tagBroken, err := flagSet.GetBool("tag-broken")
+	if err != nil {
+		return nil, err
+	}
+
+	return runner.NewCheckArgs(id, url, tags, tagBroken), nil
+}
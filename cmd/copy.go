@@ -16,13 +16,10 @@ limitations under the License.
 package cmd
 
 import (
-	"github.com/tomguerney/marks/colorizer"
-
 	"github.com/tomguerney/marks/arg"
 	"github.com/tomguerney/marks/clipper"
 	"github.com/tomguerney/marks/config"
 	"github.com/tomguerney/marks/io"
-	"github.com/tomguerney/marks/printer"
 	"github.com/tomguerney/marks/prompter"
 	"github.com/tomguerney/marks/runner"
 	"github.com/tomguerney/marks/yaml"
@@ -33,9 +30,8 @@ import (
 
 // copyCmd represents the copy command
 var copyCmd = &cobra.Command{
-	Use:   "copy id [tags...]",
+	Use:   "copy [id] [tags...]",
 	Short: "Copy a bookmark to the clipboard",
-	Args:  cobra.MinimumNArgs(1),
 	RunE:  runCopy,
 }
 
@@ -49,8 +45,10 @@ func runCopy(cmd *cobra.Command, argv []string) error {
 		return err
 	}
 	markService := yaml.NewMarkService(config, io.NewReaderWriter())
-	colorizer := colorizer.NewColorizer()
-	printer := printer.NewPrinter(config, colorizer)
+	printer, err := newPrinter(config)
+	if err != nil {
+		return err
+	}
 	prompter := prompter.NewPrompter()
 	clipper := clipper.NewClipper()
 	runner := runner.NewCopyRunner(args, config, markService, printer, prompter, clipper)
@@ -64,17 +62,28 @@ func init() {
 	rootCmd.AddCommand(copyCmd)
 	copyCmd.Flags().StringP("url", "u", "", "(can be partial) --url abc.net.au")
 	copyCmd.Flags().StringSliceP("tag", "t", []string{}, "--tag news --tag \"current affairs\"")
+	copyCmd.Flags().StringP("query", "q", "", "--query \"tag:news AND NOT tag:uk\"")
+	copyCmd.Flags().String("text", "", "matches ids, urls, tags and notes --text election")
 }
 
 func combineCopyArgs(flagSet *pflag.FlagSet, argv []string) (*runner.CopyArgs, error) {
 
 	parser := arg.NewParser(argv)
 
-	id, err := parser.Pop()
+	query, err := getQuery(flagSet)
 	if err != nil {
 		return nil, err
 	}
 
+	var id string
+	if query == "" || len(parser.Remaining()) > 0 {
+		popped, err := parser.Pop()
+		if err != nil {
+			return nil, err
+		}
+		id = popped
+	}
+
 	flagTags := parser.Remaining()
 
 	url, err := flagSet.GetString("url")
@@ -89,5 +98,5 @@ func combineCopyArgs(flagSet *pflag.FlagSet, argv []string) (*runner.CopyArgs, e
 
 	tags = append(tags, flagTags...)
 
-	return runner.NewCopyArgs(id, url, tags), nil
+	return runner.NewCopyArgs(id, url, tags, query), nil
 }
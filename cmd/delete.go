@@ -17,10 +17,8 @@ package cmd
 
 import (
 	"github.com/tomguerney/marks/arg"
-	"github.com/tomguerney/marks/colorizer"
 	"github.com/tomguerney/marks/config"
 	"github.com/tomguerney/marks/io"
-	"github.com/tomguerney/marks/printer"
 	"github.com/tomguerney/marks/prompter"
 	"github.com/tomguerney/marks/runner"
 	"github.com/tomguerney/marks/yaml"
@@ -31,9 +29,8 @@ import (
 
 // deleteCmd represents the delete command
 var deleteCmd = &cobra.Command{
-	Use:   "delete id [tags...]",
+	Use:   "delete [id] [tags...]",
 	Short: "Delete a bookmark",
-	Args:  cobra.MinimumNArgs(1),
 	RunE:  runDelete,
 }
 
@@ -47,8 +44,10 @@ func runDelete(cmd *cobra.Command, argv []string) error {
 		return err
 	}
 	markService := yaml.NewMarkService(config, io.NewReaderWriter())
-	colorizer := colorizer.NewColorizer()
-	printer := printer.NewPrinter(config, colorizer)
+	printer, err := newPrinter(config)
+	if err != nil {
+		return err
+	}
 	prompter := prompter.NewPrompter()
 	runner := runner.NewDeleteRunner(args, config, markService, printer, prompter)
 	if err := runner.Run(); err != nil {
@@ -61,17 +60,28 @@ func init() {
 	rootCmd.AddCommand(deleteCmd)
 	deleteCmd.Flags().StringP("url", "u", "", "(can be partial) --url abc.net.au")
 	deleteCmd.Flags().StringSliceP("tag", "t", []string{}, "--tag news --tag \"current affairs\"")
+	deleteCmd.Flags().StringP("query", "q", "", "--query \"tag:news AND NOT tag:uk\"")
+	deleteCmd.Flags().String("text", "", "matches ids, urls, tags and notes --text election")
 }
 
 func combineDeleteArgs(flagSet *pflag.FlagSet, argv []string) (*runner.DeleteArgs, error) {
 
 	parser := arg.NewParser(argv)
 
-	id, err := parser.Pop()
+	query, err := getQuery(flagSet)
 	if err != nil {
 		return nil, err
 	}
 
+	var id string
+	if query == "" || len(parser.Remaining()) > 0 {
+		popped, err := parser.Pop()
+		if err != nil {
+			return nil, err
+		}
+		id = popped
+	}
+
 	flagTags := parser.Remaining()
 
 	url, err := flagSet.GetString("url")
@@ -86,5 +96,5 @@ func combineDeleteArgs(flagSet *pflag.FlagSet, argv []string) (*runner.DeleteArg
 
 	tags = append(tags, flagTags...)
 
-	return runner.NewDeleteArgs(id, url, tags), nil
+	return runner.NewDeleteArgs(id, url, tags, query), nil
 }
@@ -0,0 +1,106 @@
+/*
+Copyright © 2020 NAME HERE <EMAIL ADDRESS>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"github.com/tomguerney/marks/arg"
+	"github.com/tomguerney/marks/config"
+	"github.com/tomguerney/marks/editor"
+	"github.com/tomguerney/marks/io"
+	"github.com/tomguerney/marks/prompter"
+	"github.com/tomguerney/marks/runner"
+	"github.com/tomguerney/marks/yaml"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+// editCmd represents the edit command
+var editCmd = &cobra.Command{
+	Use:   "edit [id] [tags...]",
+	Short: "Edit bookmarks as YAML in $VISUAL or $EDITOR",
+	RunE:  runEdit,
+}
+
+func runEdit(cmd *cobra.Command, argv []string) error {
+	args, err := combineEditArgs(cmd.Flags(), argv)
+	if err != nil {
+		return err
+	}
+	config, err := config.NewLoader(viper.GetViper()).Load()
+	if err != nil {
+		return err
+	}
+	markService := yaml.NewMarkService(config, io.NewReaderWriter())
+	printer, err := newPrinter(config)
+	if err != nil {
+		return err
+	}
+	prompter := prompter.NewPrompter()
+	editor := editor.NewEditor(config.Editor)
+	all, err := cmd.Flags().GetBool("all")
+	if err != nil {
+		return err
+	}
+	if all {
+		return runner.NewBulkEditRunner(args, config, markService, printer, prompter, editor).Run()
+	}
+	return runner.NewEditRunner(args, config, markService, printer, prompter, editor).Run()
+}
+
+func init() {
+	rootCmd.AddCommand(editCmd)
+	editCmd.Flags().StringP("url", "u", "", "(can be partial) --url abc.net.au")
+	editCmd.Flags().StringSliceP("tag", "t", []string{}, "--tag news --tag \"current affairs\"")
+	editCmd.Flags().StringP("query", "q", "", "--query \"tag:news AND NOT tag:uk\"")
+	editCmd.Flags().String("text", "", "matches ids, urls, tags and notes --text election")
+	editCmd.Flags().BoolP("all", "a", false, "edit as a table where bookmarks can also be created and deleted")
+}
+
+func combineEditArgs(flagSet *pflag.FlagSet, argv []string) (*runner.EditArgs, error) {
+
+	parser := arg.NewParser(argv)
+
+	var id string
+	if len(parser.Remaining()) > 0 {
+		popped, err := parser.Pop()
+		if err != nil {
+			return nil, err
+		}
+		id = popped
+	}
+
+	flagTags := parser.Remaining()
+
+	url, err := flagSet.GetString("url")
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := flagSet.GetStringSlice("tag")
+	if err != nil {
+		return nil, err
+	}
+
+	tags = append(tags, flagTags...)
+
+	query, err := getQuery(flagSet)
+	if err != nil {
+		return nil, err
+	}
+
+	return runner.NewEditArgs(id, url, tags, query), nil
+}
@@ -0,0 +1,108 @@
+/*
+Copyright © 2020 NAME HERE <EMAIL ADDRESS>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"os"
+
+	"github.com/tomguerney/marks/arg"
+	"github.com/tomguerney/marks/config"
+	"github.com/tomguerney/marks/exporter"
+	"github.com/tomguerney/marks/io"
+	"github.com/tomguerney/marks/runner"
+	"github.com/tomguerney/marks/yaml"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+// exportCmd represents the export command
+var exportCmd = &cobra.Command{
+	Use:   "export [id] [tags...]",
+	Short: "Export bookmarks for import into a browser",
+	RunE:  runExport,
+}
+
+func runExport(cmd *cobra.Command, argv []string) error {
+	args, err := combineExportArgs(cmd.Flags(), argv)
+	if err != nil {
+		return err
+	}
+	format, err := cmd.Flags().GetString("to")
+	if err != nil {
+		return err
+	}
+	primary, err := cmd.Flags().GetBool("primary-tag")
+	if err != nil {
+		return err
+	}
+	exporter, err := exporter.NewExporter(format, primary)
+	if err != nil {
+		return err
+	}
+	config, err := config.NewLoader(viper.GetViper()).Load()
+	if err != nil {
+		return err
+	}
+	markService := yaml.NewMarkService(config, io.NewReaderWriter())
+	printer, err := newPrinter(config)
+	if err != nil {
+		return err
+	}
+	runner := runner.NewExportRunner(args, config, markService, printer, exporter, os.Stdout)
+	if err := runner.Run(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+	exportCmd.Flags().String("to", "netscape", "--to netscape")
+	exportCmd.Flags().Bool("primary-tag", false, "export each bookmark once, into a folder named by its first tag")
+	exportCmd.Flags().StringP("url", "u", "", "(can be partial) --url abc.net.au")
+	exportCmd.Flags().StringSliceP("tag", "t", []string{}, "--tag news --tag \"current affairs\"")
+}
+
+func combineExportArgs(flagSet *pflag.FlagSet, argv []string) (*runner.ExportArgs, error) {
+
+	parser := arg.NewParser(argv)
+
+	var id string
+	if len(parser.Remaining()) > 0 {
+		popped, err := parser.Pop()
+		if err != nil {
+			return nil, err
+		}
+		id = popped
+	}
+
+	flagTags := parser.Remaining()
+
+	url, err := flagSet.GetString("url")
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := flagSet.GetStringSlice("tag")
+	if err != nil {
+		return nil, err
+	}
+
+	tags = append(tags, flagTags...)
+
+	return runner.NewExportArgs(id, url, tags), nil
+}
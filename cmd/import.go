@@ -0,0 +1,80 @@
+/*
+Copyright © 2020 NAME HERE <EMAIL ADDRESS>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"github.com/tomguerney/marks/arg"
+	"github.com/tomguerney/marks/config"
+	"github.com/tomguerney/marks/importer"
+	"github.com/tomguerney/marks/io"
+	"github.com/tomguerney/marks/runner"
+	"github.com/tomguerney/marks/yaml"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// importCmd represents the import command
+var importCmd = &cobra.Command{
+	Use:   "import file",
+	Short: "Import bookmarks exported from a browser",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runImport,
+}
+
+func runImport(cmd *cobra.Command, argv []string) error {
+	path, err := arg.NewParser(argv).Pop()
+	if err != nil {
+		return err
+	}
+	format, err := cmd.Flags().GetString("from")
+	if err != nil {
+		return err
+	}
+	tagPrefix, err := cmd.Flags().GetString("tag-prefix")
+	if err != nil {
+		return err
+	}
+	dryRun, err := cmd.Flags().GetBool("dry-run")
+	if err != nil {
+		return err
+	}
+	importer, err := importer.NewImporter(format, tagPrefix)
+	if err != nil {
+		return err
+	}
+	config, err := config.NewLoader(viper.GetViper()).Load()
+	if err != nil {
+		return err
+	}
+	readerWriter := io.NewReaderWriter()
+	markService := yaml.NewMarkService(config, readerWriter)
+	printer, err := newPrinter(config)
+	if err != nil {
+		return err
+	}
+	runner := runner.NewImportRunner(runner.NewImportArgs(path, dryRun), config, markService, printer, readerWriter, importer)
+	if err := runner.Run(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(importCmd)
+	importCmd.Flags().StringP("from", "f", "netscape", "--from netscape|chrome|firefox")
+	importCmd.Flags().String("tag-prefix", "", "--tag-prefix chrome/")
+	importCmd.Flags().Bool("dry-run", false, "show the bookmarks that would be imported without saving them")
+}
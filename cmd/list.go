@@ -0,0 +1,153 @@
+/*
+Copyright © 2020 NAME HERE <EMAIL ADDRESS>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"time"
+
+	"github.com/tomguerney/marks/arg"
+	"github.com/tomguerney/marks/config"
+	"github.com/tomguerney/marks/io"
+	"github.com/tomguerney/marks/runner"
+	"github.com/tomguerney/marks/yaml"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+// listCmd represents the list command
+var listCmd = &cobra.Command{
+	Use:   "list [id] [tags...]",
+	Short: "List bookmarks",
+	RunE:  runList,
+}
+
+func runList(cmd *cobra.Command, argv []string) error {
+	args, err := combineListArgs(cmd.Flags(), argv)
+	if err != nil {
+		return err
+	}
+	config, err := config.NewLoader(viper.GetViper()).Load()
+	if err != nil {
+		return err
+	}
+	markService := yaml.NewMarkService(config, io.NewReaderWriter())
+	printer, err := newPrinter(config)
+	if err != nil {
+		return err
+	}
+	runner := runner.NewListRunner(args, config, markService, printer)
+	if err := runner.Run(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(listCmd)
+	listCmd.Flags().StringP("url", "u", "", "(can be partial) --url abc.net.au")
+	listCmd.Flags().StringSliceP("tag", "t", []string{}, "--tag news --tag \"current affairs\"")
+	listCmd.Flags().StringP("query", "q", "", "--query \"tag:news AND (tag:au OR tag:uk)\"")
+	listCmd.Flags().String("text", "", "matches ids, urls, tags and notes --text election")
+	listCmd.Flags().StringP("sort", "s", "", "--sort id|url|tags|created|updated|opened")
+	listCmd.Flags().IntP("limit", "l", 0, "--limit 10")
+	listCmd.Flags().Int("offset", 0, "--offset 10")
+	listCmd.Flags().BoolP("reverse", "r", false, "--reverse")
+	listCmd.Flags().String("since", "", "created since --since 30d|2025-01-01")
+	listCmd.Flags().String("before", "", "created before --before 2025-01-01")
+	listCmd.Flags().String("opened-since", "", "last opened since --opened-since 2w")
+	listCmd.Flags().String("opened-before", "", "last opened before --opened-before 2025-01-01")
+}
+
+func combineListArgs(flagSet *pflag.FlagSet, argv []string) (*runner.ListArgs, error) {
+
+	parser := arg.NewParser(argv)
+
+	var id string
+	if len(parser.Remaining()) > 0 {
+		popped, err := parser.Pop()
+		if err != nil {
+			return nil, err
+		}
+		id = popped
+	}
+
+	flagTags := parser.Remaining()
+
+	url, err := flagSet.GetString("url")
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := flagSet.GetStringSlice("tag")
+	if err != nil {
+		return nil, err
+	}
+
+	tags = append(tags, flagTags...)
+
+	sort, err := flagSet.GetString("sort")
+	if err != nil {
+		return nil, err
+	}
+
+	limit, err := flagSet.GetInt("limit")
+	if err != nil {
+		return nil, err
+	}
+
+	offset, err := flagSet.GetInt("offset")
+	if err != nil {
+		return nil, err
+	}
+
+	reverse, err := flagSet.GetBool("reverse")
+	if err != nil {
+		return nil, err
+	}
+
+	window, err := combineTimeWindow(flagSet)
+	if err != nil {
+		return nil, err
+	}
+
+	query, err := getQuery(flagSet)
+	if err != nil {
+		return nil, err
+	}
+
+	return runner.NewListArgs(id, url, tags, sort, limit, offset, reverse, window, query), nil
+}
+
+func combineTimeWindow(flagSet *pflag.FlagSet) (*runner.TimeWindow, error) {
+	now := time.Now()
+	bounds := []time.Time{}
+	for _, name := range []string{"since", "before", "opened-since", "opened-before"} {
+		value, err := flagSet.GetString(name)
+		if err != nil {
+			return nil, err
+		}
+		var bound time.Time
+		if value != "" {
+			bound, err = arg.ParseTime(value, now)
+			if err != nil {
+				return nil, err
+			}
+		}
+		bounds = append(bounds, bound)
+	}
+	return runner.NewTimeWindow(bounds[0], bounds[1], bounds[2], bounds[3]), nil
+}
@@ -16,12 +16,12 @@ limitations under the License.
 package cmd
 
 import (
+	"strings"
+
 	"github.com/tomguerney/marks/arg"
-	"github.com/tomguerney/marks/colorizer"
 	"github.com/tomguerney/marks/config"
 	"github.com/tomguerney/marks/io"
 	"github.com/tomguerney/marks/opener"
-	"github.com/tomguerney/marks/printer"
 	"github.com/tomguerney/marks/prompter"
 	"github.com/tomguerney/marks/runner"
 	"github.com/tomguerney/marks/yaml"
@@ -32,9 +32,14 @@ import (
 
 // openCmd represents the open command
 var openCmd = &cobra.Command{
-	Use:   "open id [tags...]",
+	Use:   "open [id] [tags...]",
 	Short: "Open a url in a browser",
-	RunE:  runOpen,
+	Long: `Open a url in a browser.
+
+If id is exactly the id of a bookmark whose url is a template, the words after
+it fill in the template, as in "marks open jira PROJ-123". Otherwise they are
+matched as tags along with any --tag.`,
+	RunE: runOpen,
 }
 
 func runOpen(cmd *cobra.Command, argv []string) error {
@@ -47,8 +52,10 @@ func runOpen(cmd *cobra.Command, argv []string) error {
 		return err
 	}
 	markService := yaml.NewMarkService(config, io.NewReaderWriter())
-	colorizer := colorizer.NewColorizer()
-	printer := printer.NewPrinter(config, colorizer)
+	printer, err := newPrinter(config)
+	if err != nil {
+		return err
+	}
 	prompter := prompter.NewPrompter()
 	opener := opener.NewOpener(config)
 	runner := runner.NewOpenRunner(args, config, markService, printer, prompter, opener)
@@ -62,7 +69,12 @@ func init() {
 	rootCmd.AddCommand(openCmd)
 	openCmd.Flags().StringP("url", "u", "", "(can be partial) --url abc.net.au")
 	openCmd.Flags().StringSliceP("tag", "t", []string{}, "--tag news --tag \"current affairs\"")
-	openCmd.PersistentFlags().StringP("browser", "b", "", "--browser firefox")
+	openCmd.Flags().StringP("query", "q", "", "--query \"tag:news AND NOT tag:uk\"")
+	openCmd.Flags().String("text", "", "matches ids, urls, tags and notes --text election")
+	openCmd.Flags().BoolP("all", "a", false, "open every matching bookmark")
+	openCmd.Flags().BoolP("multi", "m", false, "pick several of the matching bookmarks to open")
+	openCmd.Flags().Bool("explain", false, "show which browser the bookmark would open in and why, without opening it")
+	openCmd.PersistentFlags().StringP("browser", "b", "", "any browser listed by marks browsers --browser firefox")
 	viper.BindPFlag("browser", openCmd.PersistentFlags().Lookup("browser"))
 }
 
@@ -70,12 +82,32 @@ func combineOpenArgs(flagSet *pflag.FlagSet, argv []string) (*runner.OpenArgs, e
 
 	parser := arg.NewParser(argv)
 
-	id, err := parser.Pop()
+	query, err := getQuery(flagSet)
+	if err != nil {
+		return nil, err
+	}
+
+	all, err := flagSet.GetBool("all")
+	if err != nil {
+		return nil, err
+	}
+
+	multi, err := flagSet.GetBool("multi")
 	if err != nil {
 		return nil, err
 	}
 
-	flagTags := parser.Remaining()
+	// An id is only required when opening a single bookmark without a query.
+	var id string
+	if (query == "" && !all && !multi) || len(parser.Remaining()) > 0 {
+		popped, err := parser.Pop()
+		if err != nil {
+			return nil, err
+		}
+		id = popped
+	}
+
+	words := parser.Remaining()
 
 	url, err := flagSet.GetString("url")
 	if err != nil {
@@ -87,7 +119,17 @@ func combineOpenArgs(flagSet *pflag.FlagSet, argv []string) (*runner.OpenArgs, e
 		return nil, err
 	}
 
-	tags = append(tags, flagTags...)
+	// A browser given on the command line takes precedence over routes, so it
+	// is passed on separately from the configured browser.
+	var browser string
+	if flag := flagSet.Lookup("browser"); flag != nil && flag.Changed {
+		browser = strings.ToLower(flag.Value.String())
+	}
+
+	explain, err := flagSet.GetBool("explain")
+	if err != nil {
+		return nil, err
+	}
 
-	return runner.NewOpenArgs(id, url, tags), nil
+	return runner.NewOpenArgs(id, url, tags, words, query, browser, explain, all, multi), nil
 }
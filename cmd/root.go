@@ -21,10 +21,16 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/tomguerney/marks/colorizer"
+	"github.com/tomguerney/marks/marks"
+	"github.com/tomguerney/marks/printer"
+	"github.com/tomguerney/marks/query"
+
 	"github.com/apex/log"
 	"github.com/apex/log/handlers/text"
 	homedir "github.com/mitchellh/go-homedir"
 
+	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 )
 
@@ -54,6 +60,16 @@ func init() {
 
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.marks.yaml)")
 	rootCmd.PersistentFlags().BoolVar(&debug, "debug", false, "output debug logs")
+	rootCmd.PersistentFlags().StringP("output", "o", "", "--output json|csv|tsv|yaml|text")
+	viper.BindPFlag("output", rootCmd.PersistentFlags().Lookup("output"))
+	rootCmd.PersistentFlags().String("format", "", "--format '{{.Id}}\\t{{.Url | truncate 40}}'")
+	viper.BindPFlag("format", rootCmd.PersistentFlags().Lookup("format"))
+	rootCmd.PersistentFlags().Bool("timestamps", false, "show created, updated and last opened times")
+	viper.BindPFlag("timestamps", rootCmd.PersistentFlags().Lookup("timestamps"))
+	rootCmd.PersistentFlags().String("rank", "", "order of matches to choose from --rank frecency|alpha|recent")
+	viper.BindPFlag("rank", rootCmd.PersistentFlags().Lookup("rank"))
+	rootCmd.PersistentFlags().Bool("fuzzy", false, "match ids, urls and tags fuzzily, tolerating typos")
+	viper.BindPFlag("fuzzy", rootCmd.PersistentFlags().Lookup("fuzzy"))
 
 }
 
@@ -88,3 +104,28 @@ func initConfig() {
 		log.Infof("Using config file: %v", viper.ConfigFileUsed())
 	}
 }
+
+// getQuery reads the query flag, joined with a text term when the text flag
+// is set.
+func getQuery(flagSet *pflag.FlagSet) (string, error) {
+	expr, err := flagSet.GetString("query")
+	if err != nil {
+		return "", err
+	}
+	text, err := flagSet.GetString("text")
+	if err != nil {
+		return "", err
+	}
+	return query.WithText(expr, text), nil
+}
+
+func newPrinter(config *marks.Config) (marks.Printer, error) {
+	colorizer := colorizer.NewColorizer()
+	if config.Format != "" {
+		return printer.NewTemplatePrinter(config, colorizer)
+	}
+	if config.Output == "text" {
+		return printer.NewPrinter(config, colorizer), nil
+	}
+	return printer.NewFormatPrinter(config, colorizer), nil
+}
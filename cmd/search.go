@@ -0,0 +1,212 @@
+/*
+Copyright © 2020 NAME HERE <EMAIL ADDRESS>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"strings"
+
+	"github.com/tomguerney/marks/arg"
+	"github.com/tomguerney/marks/config"
+	"github.com/tomguerney/marks/io"
+	"github.com/tomguerney/marks/prompter"
+	"github.com/tomguerney/marks/query"
+	"github.com/tomguerney/marks/runner"
+	"github.com/tomguerney/marks/yaml"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+// searchCmd represents the search command
+var searchCmd = &cobra.Command{
+	Use:   "search",
+	Short: "Manage saved searches",
+}
+
+// searchSaveCmd represents the search save command
+var searchSaveCmd = &cobra.Command{
+	Use:   "save name [tags...]",
+	Short: "Save a search",
+	Args:  cobra.MinimumNArgs(1),
+	RunE:  runSearchSave,
+}
+
+// searchEditCmd represents the search edit command
+var searchEditCmd = &cobra.Command{
+	Use:   "edit name [tags...]",
+	Short: "Replace the query of a saved search",
+	Args:  cobra.MinimumNArgs(1),
+	RunE:  runSearchEdit,
+}
+
+// searchRunCmd represents the search run command
+var searchRunCmd = &cobra.Command{
+	Use:   "run name",
+	Short: "List the bookmarks matching a saved search",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runSearchRun,
+}
+
+// searchListCmd represents the search list command
+var searchListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List saved searches",
+	Args:  cobra.NoArgs,
+	RunE:  runSearchList,
+}
+
+// searchDeleteCmd represents the search delete command
+var searchDeleteCmd = &cobra.Command{
+	Use:   "delete name",
+	Short: "Delete a saved search",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runSearchDelete,
+}
+
+func runSearchSave(cmd *cobra.Command, argv []string) error {
+	return runSaveSearch(cmd, argv, false)
+}
+
+func runSearchEdit(cmd *cobra.Command, argv []string) error {
+	return runSaveSearch(cmd, argv, true)
+}
+
+func runSaveSearch(cmd *cobra.Command, argv []string, edit bool) error {
+	args, err := combineSaveSearchArgs(cmd.Flags(), argv, edit)
+	if err != nil {
+		return err
+	}
+	config, err := config.NewLoader(viper.GetViper()).Load()
+	if err != nil {
+		return err
+	}
+	searchService := yaml.NewSearchService(config, io.NewReaderWriter())
+	printer, err := newPrinter(config)
+	if err != nil {
+		return err
+	}
+	runner := runner.NewSaveSearchRunner(args, config, searchService, printer)
+	if err := runner.Run(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func runSearchRun(cmd *cobra.Command, argv []string) error {
+	name := "@" + strings.TrimPrefix(argv[0], "@")
+	args := runner.NewListArgs("", "", []string{}, "", 0, 0, false, nil, name)
+	config, err := config.NewLoader(viper.GetViper()).Load()
+	if err != nil {
+		return err
+	}
+	markService := yaml.NewMarkService(config, io.NewReaderWriter())
+	printer, err := newPrinter(config)
+	if err != nil {
+		return err
+	}
+	runner := runner.NewListRunner(args, config, markService, printer)
+	if err := runner.Run(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func runSearchList(cmd *cobra.Command, argv []string) error {
+	config, err := config.NewLoader(viper.GetViper()).Load()
+	if err != nil {
+		return err
+	}
+	searchService := yaml.NewSearchService(config, io.NewReaderWriter())
+	printer, err := newPrinter(config)
+	if err != nil {
+		return err
+	}
+	runner := runner.NewListSearchesRunner(config, searchService, printer)
+	if err := runner.Run(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func runSearchDelete(cmd *cobra.Command, argv []string) error {
+	args := runner.NewDeleteSearchArgs(argv[0])
+	config, err := config.NewLoader(viper.GetViper()).Load()
+	if err != nil {
+		return err
+	}
+	searchService := yaml.NewSearchService(config, io.NewReaderWriter())
+	printer, err := newPrinter(config)
+	if err != nil {
+		return err
+	}
+	prompter := prompter.NewPrompter()
+	runner := runner.NewDeleteSearchRunner(args, config, searchService, printer, prompter)
+	if err := runner.Run(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(searchCmd)
+	searchCmd.AddCommand(searchSaveCmd)
+	searchCmd.AddCommand(searchEditCmd)
+	searchCmd.AddCommand(searchRunCmd)
+	searchCmd.AddCommand(searchListCmd)
+	searchCmd.AddCommand(searchDeleteCmd)
+	for _, c := range []*cobra.Command{searchSaveCmd, searchEditCmd} {
+		c.Flags().StringP("id", "i", "", "(can be partial) --id news")
+		c.Flags().StringP("url", "u", "", "(can be partial) --url abc.net.au")
+		c.Flags().StringSliceP("tag", "t", []string{}, "--tag news --tag \"current affairs\"")
+		c.Flags().StringP("query", "q", "", "--query \"tag:news AND NOT tag:uk\"")
+	}
+}
+
+func combineSaveSearchArgs(flagSet *pflag.FlagSet, argv []string, edit bool) (*runner.SaveSearchArgs, error) {
+
+	parser := arg.NewParser(argv)
+
+	name, err := parser.Pop()
+	if err != nil {
+		return nil, err
+	}
+
+	flagTags := parser.Remaining()
+
+	id, err := flagSet.GetString("id")
+	if err != nil {
+		return nil, err
+	}
+
+	url, err := flagSet.GetString("url")
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := flagSet.GetStringSlice("tag")
+	if err != nil {
+		return nil, err
+	}
+
+	tags = append(tags, flagTags...)
+
+	expr, err := flagSet.GetString("query")
+	if err != nil {
+		return nil, err
+	}
+
+	return runner.NewSaveSearchArgs(name, query.Combine(expr, id, url, tags), edit), nil
+}
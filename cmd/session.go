@@ -0,0 +1,161 @@
+/*
+Copyright © 2020 NAME HERE <EMAIL ADDRESS>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"strings"
+
+	"github.com/tomguerney/marks/config"
+	"github.com/tomguerney/marks/io"
+	"github.com/tomguerney/marks/opener"
+	"github.com/tomguerney/marks/prompter"
+	"github.com/tomguerney/marks/runner"
+	"github.com/tomguerney/marks/yaml"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// sessionCmd represents the session command
+var sessionCmd = &cobra.Command{
+	Use:   "session",
+	Short: "Manage sessions of bookmarks that are opened together",
+}
+
+// sessionSaveCmd represents the session save command
+var sessionSaveCmd = &cobra.Command{
+	Use:   "save name id...",
+	Short: "Save the bookmarks with the given ids, in order, as a session",
+	Args:  cobra.MinimumNArgs(2),
+	RunE:  runSessionSave,
+}
+
+// sessionOpenCmd represents the session open command
+var sessionOpenCmd = &cobra.Command{
+	Use:   "open name",
+	Short: "Open every bookmark in a session",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runSessionOpen,
+}
+
+// sessionListCmd represents the session list command
+var sessionListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List sessions",
+	Args:  cobra.NoArgs,
+	RunE:  runSessionList,
+}
+
+// sessionDeleteCmd represents the session delete command
+var sessionDeleteCmd = &cobra.Command{
+	Use:   "delete name",
+	Short: "Delete a session",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runSessionDelete,
+}
+
+func runSessionSave(cmd *cobra.Command, argv []string) error {
+	args := runner.NewSaveSessionArgs(argv[0], argv[1:])
+	config, err := config.NewLoader(viper.GetViper()).Load()
+	if err != nil {
+		return err
+	}
+	markService := yaml.NewMarkService(config, io.NewReaderWriter())
+	sessionService := yaml.NewSessionService(config, io.NewReaderWriter())
+	printer, err := newPrinter(config)
+	if err != nil {
+		return err
+	}
+	runner := runner.NewSaveSessionRunner(args, config, markService, sessionService, printer)
+	if err := runner.Run(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func runSessionOpen(cmd *cobra.Command, argv []string) error {
+	browser, err := cmd.Flags().GetString("browser")
+	if err != nil {
+		return err
+	}
+	explain, err := cmd.Flags().GetBool("explain")
+	if err != nil {
+		return err
+	}
+	args := runner.NewOpenSessionArgs(argv[0], strings.ToLower(browser), explain)
+	config, err := config.NewLoader(viper.GetViper()).Load()
+	if err != nil {
+		return err
+	}
+	markService := yaml.NewMarkService(config, io.NewReaderWriter())
+	sessionService := yaml.NewSessionService(config, io.NewReaderWriter())
+	printer, err := newPrinter(config)
+	if err != nil {
+		return err
+	}
+	prompter := prompter.NewPrompter()
+	opener := opener.NewOpener(config)
+	runner := runner.NewOpenSessionRunner(args, config, markService, sessionService, printer, prompter, opener)
+	if err := runner.Run(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func runSessionList(cmd *cobra.Command, argv []string) error {
+	config, err := config.NewLoader(viper.GetViper()).Load()
+	if err != nil {
+		return err
+	}
+	sessionService := yaml.NewSessionService(config, io.NewReaderWriter())
+	printer, err := newPrinter(config)
+	if err != nil {
+		return err
+	}
+	runner := runner.NewListSessionsRunner(config, sessionService, printer)
+	if err := runner.Run(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func runSessionDelete(cmd *cobra.Command, argv []string) error {
+	args := runner.NewDeleteSessionArgs(argv[0])
+	config, err := config.NewLoader(viper.GetViper()).Load()
+	if err != nil {
+		return err
+	}
+	sessionService := yaml.NewSessionService(config, io.NewReaderWriter())
+	printer, err := newPrinter(config)
+	if err != nil {
+		return err
+	}
+	prompter := prompter.NewPrompter()
+	runner := runner.NewDeleteSessionRunner(args, config, sessionService, printer, prompter)
+	if err := runner.Run(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(sessionCmd)
+	sessionCmd.AddCommand(sessionSaveCmd)
+	sessionCmd.AddCommand(sessionOpenCmd)
+	sessionCmd.AddCommand(sessionListCmd)
+	sessionCmd.AddCommand(sessionDeleteCmd)
+	sessionOpenCmd.Flags().StringP("browser", "b", "", "any browser listed by marks browsers --browser firefox")
+	sessionOpenCmd.Flags().Bool("explain", false, "show which browser each bookmark would open in and why, without opening them")
+}
@@ -0,0 +1,178 @@
+/*
+Copyright © 2020 NAME HERE <EMAIL ADDRESS>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"github.com/tomguerney/marks/config"
+	"github.com/tomguerney/marks/io"
+	"github.com/tomguerney/marks/prompter"
+	"github.com/tomguerney/marks/runner"
+	"github.com/tomguerney/marks/yaml"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// tagsCmd represents the tags command
+var tagsCmd = &cobra.Command{
+	Use:   "tags",
+	Short: "Manage tags across all bookmarks",
+}
+
+// tagsListCmd represents the tags list command
+var tagsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List tags with the number of bookmarks using each",
+	Args:  cobra.NoArgs,
+	RunE:  runTagsList,
+}
+
+// tagsTreeCmd represents the tags tree command
+var tagsTreeCmd = &cobra.Command{
+	Use:   "tree",
+	Short: "Show the tag hierarchy with the number of bookmarks under each tag",
+	Args:  cobra.NoArgs,
+	RunE:  runTagsTree,
+}
+
+// tagsNormalizeCmd represents the tags normalize command
+var tagsNormalizeCmd = &cobra.Command{
+	Use:   "normalize",
+	Short: "Rewrite every bookmark's tags in their canonical form",
+	Args:  cobra.NoArgs,
+	RunE:  runTagsNormalize,
+}
+
+// tagsRenameCmd represents the tags rename command
+var tagsRenameCmd = &cobra.Command{
+	Use:   "rename old new",
+	Short: "Rename a tag and its descendants on every bookmark",
+	Args:  cobra.ExactArgs(2),
+	RunE:  runTagsRename,
+}
+
+// tagsMergeCmd represents the tags merge command
+var tagsMergeCmd = &cobra.Command{
+	Use:   "merge tags... --into tag",
+	Short: "Replace several tags with one on every bookmark",
+	Args:  cobra.MinimumNArgs(1),
+	RunE:  runTagsMerge,
+}
+
+// tagsDeleteCmd represents the tags delete command
+var tagsDeleteCmd = &cobra.Command{
+	Use:   "delete tags...",
+	Short: "Remove tags from every bookmark",
+	Args:  cobra.MinimumNArgs(1),
+	RunE:  runTagsDelete,
+}
+
+func runTagsList(cmd *cobra.Command, argv []string) error {
+	config, err := config.NewLoader(viper.GetViper()).Load()
+	if err != nil {
+		return err
+	}
+	markService := yaml.NewMarkService(config, io.NewReaderWriter())
+	printer, err := newPrinter(config)
+	if err != nil {
+		return err
+	}
+	runner := runner.NewListTagsRunner(config, markService, printer)
+	if err := runner.Run(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func runTagsTree(cmd *cobra.Command, argv []string) error {
+	config, err := config.NewLoader(viper.GetViper()).Load()
+	if err != nil {
+		return err
+	}
+	markService := yaml.NewMarkService(config, io.NewReaderWriter())
+	printer, err := newPrinter(config)
+	if err != nil {
+		return err
+	}
+	runner := runner.NewTreeTagsRunner(config, markService, printer)
+	if err := runner.Run(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func runTagsNormalize(cmd *cobra.Command, argv []string) error {
+	config, err := config.NewLoader(viper.GetViper()).Load()
+	if err != nil {
+		return err
+	}
+	markService := yaml.NewMarkService(config, io.NewReaderWriter())
+	printer, err := newPrinter(config)
+	if err != nil {
+		return err
+	}
+	prompter := prompter.NewPrompter()
+	runner := runner.NewNormalizeTagsRunner(config, markService, printer, prompter)
+	if err := runner.Run(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func runTagsRename(cmd *cobra.Command, argv []string) error {
+	return runRetag(runner.NewRenameTagArgs(argv[0], argv[1]))
+}
+
+func runTagsMerge(cmd *cobra.Command, argv []string) error {
+	into, err := cmd.Flags().GetString("into")
+	if err != nil {
+		return err
+	}
+	return runRetag(runner.NewMergeTagsArgs(argv, into))
+}
+
+func runTagsDelete(cmd *cobra.Command, argv []string) error {
+	return runRetag(runner.NewDeleteTagsArgs(argv))
+}
+
+func runRetag(args *runner.RetagArgs) error {
+	config, err := config.NewLoader(viper.GetViper()).Load()
+	if err != nil {
+		return err
+	}
+	markService := yaml.NewMarkService(config, io.NewReaderWriter())
+	printer, err := newPrinter(config)
+	if err != nil {
+		return err
+	}
+	prompter := prompter.NewPrompter()
+	runner := runner.NewRetagRunner(args, config, markService, printer, prompter)
+	if err := runner.Run(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(tagsCmd)
+	tagsCmd.AddCommand(tagsListCmd)
+	tagsCmd.AddCommand(tagsTreeCmd)
+	tagsCmd.AddCommand(tagsNormalizeCmd)
+	tagsCmd.AddCommand(tagsRenameCmd)
+	tagsCmd.AddCommand(tagsMergeCmd)
+	tagsCmd.AddCommand(tagsDeleteCmd)
+	tagsMergeCmd.Flags().String("into", "", "--into news")
+	tagsMergeCmd.MarkFlagRequired("into")
+}
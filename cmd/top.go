@@ -0,0 +1,77 @@
+/*
+Copyright © 2020 NAME HERE <EMAIL ADDRESS>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"github.com/tomguerney/marks/config"
+	"github.com/tomguerney/marks/io"
+	"github.com/tomguerney/marks/runner"
+	"github.com/tomguerney/marks/yaml"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+// topCmd represents the top command
+var topCmd = &cobra.Command{
+	Use:   "top [tags...]",
+	Short: "List the most used bookmarks",
+	RunE:  runTop,
+}
+
+func runTop(cmd *cobra.Command, argv []string) error {
+	args, err := combineTopArgs(cmd.Flags(), argv)
+	if err != nil {
+		return err
+	}
+	config, err := config.NewLoader(viper.GetViper()).Load()
+	if err != nil {
+		return err
+	}
+	markService := yaml.NewMarkService(config, io.NewReaderWriter())
+	printer, err := newPrinter(config)
+	if err != nil {
+		return err
+	}
+	runner := runner.NewTopRunner(args, config, markService, printer)
+	if err := runner.Run(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(topCmd)
+	topCmd.Flags().StringSliceP("tag", "t", []string{}, "--tag news --tag \"current affairs\"")
+	topCmd.Flags().IntP("limit", "l", 10, "--limit 10")
+}
+
+func combineTopArgs(flagSet *pflag.FlagSet, argv []string) (*runner.TopArgs, error) {
+
+	tags, err := flagSet.GetStringSlice("tag")
+	if err != nil {
+		return nil, err
+	}
+
+	tags = append(tags, argv...)
+
+	limit, err := flagSet.GetInt("limit")
+	if err != nil {
+		return nil, err
+	}
+
+	return runner.NewTopArgs(tags, limit), nil
+}
@@ -17,10 +17,9 @@ package cmd
 
 import (
 	"github.com/tomguerney/marks/arg"
-	"github.com/tomguerney/marks/colorizer"
 	"github.com/tomguerney/marks/config"
+	"github.com/tomguerney/marks/editor"
 	"github.com/tomguerney/marks/io"
-	"github.com/tomguerney/marks/printer"
 	"github.com/tomguerney/marks/prompter"
 	"github.com/tomguerney/marks/runner"
 	"github.com/tomguerney/marks/yaml"
@@ -31,7 +30,7 @@ import (
 
 // updateCmd represents the update command
 var updateCmd = &cobra.Command{
-	Use:   "update id [tags...]",
+	Use:   "update [id] [tags...]",
 	Short: "Update a bookmark",
 	RunE:  runUpdate,
 }
@@ -46,10 +45,13 @@ func runUpdate(cmd *cobra.Command, argv []string) error {
 		return err
 	}
 	markService := yaml.NewMarkService(config, io.NewReaderWriter())
-	colorizer := colorizer.NewColorizer()
-	printer := printer.NewPrinter(config, colorizer)
+	printer, err := newPrinter(config)
+	if err != nil {
+		return err
+	}
 	prompter := prompter.NewPrompter()
-	runner := runner.NewUpdateRunner(args, config, markService, printer, prompter)
+	editor := editor.NewEditor(config.Editor)
+	runner := runner.NewUpdateRunner(args, config, markService, printer, prompter, editor)
 	if err := runner.Run(); err != nil {
 		return err
 	}
@@ -60,22 +62,38 @@ func init() {
 	rootCmd.AddCommand(updateCmd)
 	updateCmd.Flags().StringP("url", "u", "", "(can be partial) --url abc.net.au")
 	updateCmd.Flags().StringSliceP("tag", "t", []string{}, "--tag news --tag \"current affairs\"")
+	updateCmd.Flags().StringP("query", "q", "", "--query \"tag:news AND NOT tag:uk\"")
+	updateCmd.Flags().String("text", "", "matches ids, urls, tags and notes --text election")
 	updateCmd.Flags().StringP("new-id", "", "", "--new-id \"Public News Service\"")
 	updateCmd.Flags().StringP("new-url", "", "", "--new-url https://www.abc.net.au/news")
 	updateCmd.Flags().StringSliceP("new-tag", "", []string{}, "--new-tag free")
 	updateCmd.Flags().StringSliceP("remove-tag", "", []string{}, "--remove-tag \"current affairs\"")
 	updateCmd.Flags().Bool("remove-url", false, "--remove-url")
+	updateCmd.Flags().String("note", "", "replace the notes --note \"Saved for the election coverage\"")
+	updateCmd.Flags().String("append-note", "", "add a line to the notes --append-note \"Check the live blog\"")
+	updateCmd.Flags().Bool("edit-note", false, "edit the notes in $VISUAL or $EDITOR")
+	updateCmd.Flags().String("browser", "", "always open in this browser, any listed by marks browsers --browser firefox")
+	updateCmd.Flags().Bool("remove-browser", false, "open in the browser chosen by routes and config again")
 }
 
 func combineUpdateArgs(flagSet *pflag.FlagSet, argv []string) (*runner.UpdateArgs, error) {
 
 	parser := arg.NewParser(argv)
 
-	id, err := parser.Pop()
+	query, err := getQuery(flagSet)
 	if err != nil {
 		return nil, err
 	}
 
+	var id string
+	if query == "" || len(parser.Remaining()) > 0 {
+		popped, err := parser.Pop()
+		if err != nil {
+			return nil, err
+		}
+		id = popped
+	}
+
 	flagTags := parser.Remaining()
 
 	url, err := flagSet.GetString("url")
@@ -115,6 +133,31 @@ func combineUpdateArgs(flagSet *pflag.FlagSet, argv []string) (*runner.UpdateArg
 		return nil, err
 	}
 
+	note, err := flagSet.GetString("note")
+	if err != nil {
+		return nil, err
+	}
+
+	appendNote, err := flagSet.GetString("append-note")
+	if err != nil {
+		return nil, err
+	}
+
+	editNote, err := flagSet.GetBool("edit-note")
+	if err != nil {
+		return nil, err
+	}
+
+	browser, err := flagSet.GetString("browser")
+	if err != nil {
+		return nil, err
+	}
+
+	removeBrowser, err := flagSet.GetBool("remove-browser")
+	if err != nil {
+		return nil, err
+	}
+
 	return runner.NewUpdateArgs(
 		id,
 		url,
@@ -124,5 +167,11 @@ func combineUpdateArgs(flagSet *pflag.FlagSet, argv []string) (*runner.UpdateArg
 		newTags,
 		removeTags,
 		removeUrl,
+		note,
+		appendNote,
+		editNote,
+		browser,
+		removeBrowser,
+		query,
 	), nil
 }
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/tomguerney/marks/marks"
@@ -13,6 +14,11 @@ type loader struct {
 
 type provider interface {
 	GetString(string) string
+	GetBool(string) bool
+	GetInt(string) int
+	Get(string) interface{}
+	GetStringMap(string) map[string]interface{}
+	GetStringMapString(string) map[string]string
 	SetDefault(string, interface{})
 }
 
@@ -46,42 +52,147 @@ func (l *loader) loadConfig() *marks.Config {
 
 func (l *loader) setDefaults() {
 	l.SetDefault("yaml", "bookmarks.yaml")
+	l.SetDefault("searchesYaml", "searches.yaml")
+	l.SetDefault("sessionsYaml", "sessions.yaml")
 	l.SetDefault("idColor", "green")
 	l.SetDefault("urlColor", "blue")
 	l.SetDefault("tagsColor", "yellow")
 	l.SetDefault("browserColor", "red")
-	l.SetDefault("chromeOpenArgs", "-a \"Google Chrome\" {{.Url}}")
-	l.SetDefault("firefoxOpenargs", "-a firefox {{.Url}}")
-	l.SetDefault("browser", "chrome")
+	for browser, command := range launchCommands(goos) {
+		l.SetDefault(browser+"Command", command)
+	}
+	l.SetDefault("browser", "default")
+	l.SetDefault("openConfirmThreshold", 5)
+	l.SetDefault("output", "text")
+	l.SetDefault("tagSeparator", "/")
+	l.SetDefault("tagWordSeparator", "-")
 }
 
 func (l *loader) loadUserConfig() *marks.UserConfig {
 	return &marks.UserConfig{
-		ContentPath:     l.GetString("contentpath"),
-		MarksYamlFile:   l.GetString("yaml"),
-		ChromeOpenArgs:  l.GetString("chromeOpenArgs"),
-		FirefoxOpenArgs: l.GetString("firefoxOpenargs"),
-		IdColor:         strings.ToLower(l.GetString("idColor")),
-		UrlColor:        strings.ToLower(l.GetString("urlColor")),
-		TagsColor:       strings.ToLower(l.GetString("tagsColor")),
-		BrowserColor:    strings.ToLower(l.GetString("browserColor")),
-		Browser:         strings.ToLower(l.GetString("browser")),
+		ContentPath:      l.GetString("contentpath"),
+		MarksYamlFile:    l.GetString("yaml"),
+		SearchesYamlFile: l.GetString("searchesYaml"),
+		SessionsYamlFile: l.GetString("sessionsYaml"),
+		Browsers:         l.loadBrowsers(),
+		Routes:           l.loadRoutes(),
+		OpenThreshold:    l.GetInt("openConfirmThreshold"),
+		IdColor:          strings.ToLower(l.GetString("idColor")),
+		UrlColor:         strings.ToLower(l.GetString("urlColor")),
+		TagsColor:        strings.ToLower(l.GetString("tagsColor")),
+		BrowserColor:     strings.ToLower(l.GetString("browserColor")),
+		Browser:          strings.ToLower(l.GetString("browser")),
+		Output:           strings.ToLower(l.GetString("output")),
+		Format:           l.GetString("format"),
+		ShowTimestamps:   l.GetBool("timestamps"),
+		Rank:             l.rank(),
+		Fuzzy:            l.GetBool("fuzzy"),
+		TagSeparator:     l.GetString("tagSeparator"),
+		NormalizeTags:    l.GetBool("normalizeTags"),
+		TagWordSeparator: l.GetString("tagWordSeparator"),
+		TagAliases:       l.GetStringMapString("tagAliases"),
+		Editor:           l.GetString("editorCommand"),
+	}
+}
+
+// rank returns the ranking to order matches by. Fuzzy matches are already
+// ordered by how well they match, so they are only ranked when a ranking is
+// given explicitly.
+func (l *loader) rank() string {
+	rank := strings.ToLower(l.GetString("rank"))
+	if rank == "" && !l.GetBool("fuzzy") {
+		return "frecency"
+	}
+	return rank
+}
+
+// loadBrowsers returns the built in browsers along with those defined under
+// "browsers", which replace any built in browser of the same name.
+func (l *loader) loadBrowsers() map[string]*marks.Browser {
+	browsers := map[string]*marks.Browser{}
+	for name := range launchCommands(goos) {
+		browsers[name] = &marks.Browser{Name: name, Command: l.launchCommand(name)}
+	}
+	for name, definition := range l.GetStringMap("browsers") {
+		name = strings.ToLower(name)
+		fields := stringFields(definition)
+		browsers[name] = &marks.Browser{
+			Name:    name,
+			Command: fields["command"],
+			Args:    fields["args"],
+			Profile: fields["profile"],
+		}
+	}
+	return browsers
+}
+
+// loadRoutes reads the ordered list of routes under "routes".
+func (l *loader) loadRoutes() []*marks.Route {
+	routes := []*marks.Route{}
+	definitions, _ := l.Get("routes").([]interface{})
+	for _, definition := range definitions {
+		fields := stringFields(definition)
+		routes = append(routes, &marks.Route{
+			Tag:     fields["tag"],
+			Host:    fields["host"],
+			Url:     fields["url"],
+			Browser: strings.ToLower(fields["browser"]),
+			Profile: fields["profile"],
+		})
+	}
+	return routes
+}
+
+// stringFields reads a mapping from the config file, which may be decoded
+// with either string or interface keys. Keys left blank, as in "args:",
+// are treated as missing.
+func stringFields(definition interface{}) map[string]string {
+	fields := map[string]string{}
+	switch definition := definition.(type) {
+	case map[string]interface{}:
+		for k, v := range definition {
+			if v != nil {
+				fields[strings.ToLower(k)] = fmt.Sprint(v)
+			}
+		}
+	case map[interface{}]interface{}:
+		for k, v := range definition {
+			if v != nil {
+				fields[strings.ToLower(fmt.Sprint(k))] = fmt.Sprint(v)
+			}
+		}
+	}
+	return fields
+}
+
+// launchCommand returns the command that opens a url in browser. The older
+// OpenArgs settings only held the arguments to the macOS open command.
+func (l *loader) launchCommand(browser string) string {
+	if args := l.GetString(browser + "OpenArgs"); args != "" {
+		return "open " + args
 	}
+	return l.GetString(browser + "Command")
 }
 
 func (l *loader) loadAppConfg() *marks.AppConfig {
 	return &marks.AppConfig{
 		FullFormat:        []string{"id", "url", "tags"},
 		MarksYamlFileMode: 0644,
-		SupportedBrowsers: []string{"chrome", "firefox"},
 		SupportedColors:   []string{"black", "red", "green", "yellow", "blue", "magenta", "cyan", "white"},
+		SupportedOutputs:  []string{"text", "json", "csv", "tsv", "yaml"},
+		SupportedRanks:    []string{"frecency", "alpha", "recent"},
 	}
 }
 
 func (l *loader) loadValidationRules() []validationRule {
 	return []validationRule{
 		browserMustBeSupported,
+		browsersMustBeValid,
+		routesMustBeValid,
 		colorsMustBeSupported,
+		outputMustBeSupported,
+		rankMustBeSupported,
+		tagAliasesMustBeCanonical,
 	}
 }
 
@@ -2,6 +2,7 @@ package config
 
 import (
 	"errors"
+	"reflect"
 	"testing"
 
 	"github.com/tomguerney/marks/mocks"
@@ -11,6 +12,9 @@ import (
 
 type mockProvider struct {
 	getStringFn      func(string) string
+	getFn            func(string) interface{}
+	getBoolFn        func(string) bool
+	getStringMapFn   func(string) map[string]interface{}
 	getStringCalled  bool
 	setDefaultCalled bool
 }
@@ -20,6 +24,35 @@ func (p *mockProvider) GetString(s string) string {
 	return p.getStringFn(s)
 }
 
+func (p *mockProvider) GetBool(s string) bool {
+	if p.getBoolFn == nil {
+		return false
+	}
+	return p.getBoolFn(s)
+}
+
+func (p *mockProvider) Get(s string) interface{} {
+	if p.getFn == nil {
+		return nil
+	}
+	return p.getFn(s)
+}
+
+func (p *mockProvider) GetStringMap(s string) map[string]interface{} {
+	if p.getStringMapFn == nil {
+		return map[string]interface{}{}
+	}
+	return p.getStringMapFn(s)
+}
+
+func (p *mockProvider) GetInt(s string) int {
+	return 0
+}
+
+func (p *mockProvider) GetStringMapString(s string) map[string]string {
+	return map[string]string{}
+}
+
 func (p *mockProvider) SetDefault(s string, i interface{}) {
 	p.setDefaultCalled = true
 }
@@ -137,3 +170,131 @@ func TestValidatorFail(t *testing.T) {
 		t.Fatal("Should cause error")
 	}
 }
+
+func TestLaunchCommand(t *testing.T) {
+	p := newMockProvider()
+	p.getStringFn = func(s string) string {
+		switch s {
+		case "chromeOpenArgs":
+			return "-a \"Google Chrome\" {{.Url}}"
+		case "firefoxCommand":
+			return "firefox --new-tab {{.Url}}"
+		}
+		return ""
+	}
+	loader := loader{p, newMockValidator()}
+	if actual := loader.launchCommand("chrome"); actual != "open -a \"Google Chrome\" {{.Url}}" {
+		t.Fatalf("unexpected chrome command %v", actual)
+	}
+	if actual := loader.launchCommand("firefox"); actual != "firefox --new-tab {{.Url}}" {
+		t.Fatalf("unexpected firefox command %v", actual)
+	}
+}
+
+func TestLaunchCommands(t *testing.T) {
+	expected := map[string]string{
+		"darwin":  "open {{.Url}}",
+		"linux":   "xdg-open {{.Url}}",
+		"freebsd": "xdg-open {{.Url}}",
+		"windows": "rundll32 url.dll,FileProtocolHandler {{.Url}}",
+	}
+	for goos, command := range expected {
+		commands := launchCommands(goos)
+		if commands["default"] != command {
+			t.Fatalf("expected %v on %v, received %v", command, goos, commands["default"])
+		}
+		if commands["chrome"] == "" || commands["firefox"] == "" {
+			t.Fatalf("expected browser commands on %v", goos)
+		}
+	}
+}
+
+func TestLoadBrowsers(t *testing.T) {
+	p := newMockProvider()
+	p.getStringMapFn = func(s string) map[string]interface{} {
+		return map[string]interface{}{
+			"Brave": map[string]interface{}{
+				"command": "brave-browser",
+				"args":    "--profile-directory={{.Profile}} {{.Url}}",
+				"profile": "Work",
+			},
+			"chrome": map[interface{}]interface{}{"command": "chromium"},
+		}
+	}
+	loader := loader{p, newMockValidator()}
+	browsers := loader.loadBrowsers()
+	expected := &marks.Browser{
+		Name:    "brave",
+		Command: "brave-browser",
+		Args:    "--profile-directory={{.Profile}} {{.Url}}",
+		Profile: "Work",
+	}
+	if !reflect.DeepEqual(browsers["brave"], expected) {
+		t.Fatalf("expected %v, received %v", expected, browsers["brave"])
+	}
+	if browsers["chrome"].Command != "chromium" {
+		t.Fatalf("expected chrome to be replaced, received %v", browsers["chrome"])
+	}
+	if browsers["default"] == nil || browsers["firefox"] == nil {
+		t.Fatal("built in browsers should be kept")
+	}
+}
+
+func TestLoadBrowserWithBlankFields(t *testing.T) {
+	p := newMockProvider()
+	p.getStringMapFn = func(s string) map[string]interface{} {
+		return map[string]interface{}{
+			"lynx": map[interface{}]interface{}{"command": "lynx {{.Url}}", "args": nil, "profile": nil},
+		}
+	}
+	loader := loader{p, newMockValidator()}
+	expected := &marks.Browser{Name: "lynx", Command: "lynx {{.Url}}"}
+	if actual := loader.loadBrowsers()["lynx"]; !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %v, received %v", expected, actual)
+	}
+}
+
+func TestLoadRoutes(t *testing.T) {
+	p := newMockProvider()
+	p.getFn = func(s string) interface{} {
+		return []interface{}{
+			map[interface{}]interface{}{"tag": "work", "browser": "Chrome", "profile": "Work"},
+			map[string]interface{}{"host": "*.atlassian.net", "browser": "chrome"},
+		}
+	}
+	loader := loader{p, newMockValidator()}
+	expected := []*marks.Route{
+		&marks.Route{Tag: "work", Browser: "chrome", Profile: "Work"},
+		&marks.Route{Host: "*.atlassian.net", Browser: "chrome"},
+	}
+	if actual := loader.loadRoutes(); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %v, received %v", expected, actual)
+	}
+}
+
+func TestLoadRank(t *testing.T) {
+	for _, test := range []struct {
+		rank     string
+		fuzzy    bool
+		expected string
+	}{
+		{"", false, "frecency"},
+		{"", true, ""},
+		{"Alpha", true, "alpha"},
+	} {
+		p := newMockProvider()
+		p.getStringFn = func(s string) string {
+			if s == "rank" {
+				return test.rank
+			}
+			return ""
+		}
+		p.getBoolFn = func(s string) bool {
+			return s == "fuzzy" && test.fuzzy
+		}
+		loader := loader{p, newMockValidator()}
+		if rank := loader.rank(); rank != test.expected {
+			t.Fatalf("expected %q for %q with fuzzy %v, received %q", test.expected, test.rank, test.fuzzy, rank)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package config
+
+import "runtime"
+
+var goos = runtime.GOOS
+
+// chromeProfile passes Chrome the profile directory to open in, if one is
+// set. The opener leaves the argument out otherwise.
+const chromeProfile = "\"{{if .Profile}}--profile-directory={{.Profile}}{{end}}\""
+
+// launchCommands returns the command used to open a url in each supported
+// browser on the given platform.
+func launchCommands(goos string) map[string]string {
+	switch goos {
+	case "darwin":
+		return map[string]string{
+			"default": "open {{.Url}}",
+			"chrome":  "open -na \"Google Chrome\" --args " + chromeProfile + " {{.Url}}",
+			"firefox": "open -a Firefox {{.Url}}",
+		}
+	case "windows":
+		return map[string]string{
+			"default": "rundll32 url.dll,FileProtocolHandler {{.Url}}",
+			"chrome":  "cmd /c start chrome " + chromeProfile + " {{.Url}}",
+			"firefox": "cmd /c start firefox {{.Url}}",
+		}
+	default:
+		return map[string]string{
+			"default": "xdg-open {{.Url}}",
+			"chrome":  "google-chrome " + chromeProfile + " {{.Url}}",
+			"firefox": "firefox --new-tab {{.Url}}",
+		}
+	}
+}
@@ -3,20 +3,55 @@ package config
 import (
 	"errors"
 	"fmt"
+	"path"
+	"regexp"
+	"strings"
 
 	"github.com/tomguerney/marks/marks"
 )
 
 var browserMustBeSupported = func(c *marks.Config) error {
 	browser := c.UserConfig.Browser
-	for _, supportedBrowser := range c.AppConfig.SupportedBrowsers {
-		if browser == supportedBrowser {
-			return nil
-		}
+	if _, ok := c.UserConfig.Browsers[browser]; ok {
+		return nil
 	}
 	return errors.New(fmt.Sprintf("%v is not a supported browser", browser))
 }
 
+var browsersMustBeValid = func(c *marks.Config) error {
+	for name, browser := range c.UserConfig.Browsers {
+		if browser.Profile != "" && !browser.UsesProfile() {
+			return errors.New(fmt.Sprintf("browser %v sets profile %v but its args do not use {{.Profile}}", name, browser.Profile))
+		}
+		if err := browser.Validate(); err != nil {
+			return errors.New(fmt.Sprintf("Browser \"%v\" is not valid: %v", name, err.Error()))
+		}
+	}
+	return nil
+}
+
+var routesMustBeValid = func(c *marks.Config) error {
+	for i, route := range c.UserConfig.Routes {
+		if route.Tag == "" && route.Host == "" && route.Url == "" {
+			return errors.New(fmt.Sprintf("route %v has no tag, host or url to match", i+1))
+		}
+		browser, ok := c.UserConfig.Browsers[route.Browser]
+		if !ok {
+			return errors.New(fmt.Sprintf("route %v uses %v, which is not a supported browser", i+1, route.Browser))
+		}
+		if route.Profile != "" && !browser.UsesProfile() {
+			return errors.New(fmt.Sprintf("route %v sets profile %v but the args of %v do not use {{.Profile}}", i+1, route.Profile, route.Browser))
+		}
+		if _, err := path.Match(route.Host, ""); err != nil {
+			return errors.New(fmt.Sprintf("route %v host %v is not a valid pattern", i+1, route.Host))
+		}
+		if _, err := regexp.Compile(route.Url); err != nil {
+			return errors.New(fmt.Sprintf("route %v url %v is not a valid regular expression: %v", i+1, route.Url, err.Error()))
+		}
+	}
+	return nil
+}
+
 var colorsMustBeSupported = func(c *marks.Config) error {
 
 	colorSupported := func(userColor string) bool {
@@ -45,3 +80,40 @@ var colorsMustBeSupported = func(c *marks.Config) error {
 		return errors.New(fmt.Sprintf("%v is not a supported color", color))
 	}
 }
+
+var outputMustBeSupported = func(c *marks.Config) error {
+	output := c.UserConfig.Output
+	for _, supportedOutput := range c.AppConfig.SupportedOutputs {
+		if output == supportedOutput {
+			return nil
+		}
+	}
+	return errors.New(fmt.Sprintf("%v is not a supported output format", output))
+}
+
+var rankMustBeSupported = func(c *marks.Config) error {
+	rank := c.UserConfig.Rank
+	if rank == "" {
+		return nil
+	}
+	for _, supportedRank := range c.AppConfig.SupportedRanks {
+		if rank == supportedRank {
+			return nil
+		}
+	}
+	return errors.New(fmt.Sprintf("%v is not a supported rank", rank))
+}
+
+var tagAliasesMustBeCanonical = func(c *marks.Config) error {
+	normalizer := marks.NewTagNormalizer(c)
+	for from, to := range c.UserConfig.TagAliases {
+		if strings.TrimSpace(to) == "" {
+			return errors.New(fmt.Sprintf("tag alias %v has no tag to replace it with", from))
+		}
+		once := normalizer.Normalize(from)
+		if normalizer.Normalize(once) != once {
+			return errors.New(fmt.Sprintf("tag alias %v -> %v refers to another alias", from, to))
+		}
+	}
+	return nil
+}
@@ -3,12 +3,13 @@ package config
 import (
 	"testing"
 
+	"github.com/tomguerney/marks/marks"
 	"github.com/tomguerney/marks/mocks"
 )
 
 func TestBrowserMustBeSupportedPass(t *testing.T) {
 	config := mocks.NewConfig()
-	config.AppConfig.SupportedBrowsers = []string{"one", "two"}
+	config.UserConfig.Browsers = map[string]*marks.Browser{"one": &marks.Browser{}, "two": &marks.Browser{}}
 	config.UserConfig.Browser = "two"
 	err := browserMustBeSupported(config)
 	if err != nil {
@@ -18,7 +19,7 @@ func TestBrowserMustBeSupportedPass(t *testing.T) {
 
 func TestBrowserMustBeSupportedFail(t *testing.T) {
 	config := mocks.NewConfig()
-	config.AppConfig.SupportedBrowsers = []string{"one", "two"}
+	config.UserConfig.Browsers = map[string]*marks.Browser{"one": &marks.Browser{}, "two": &marks.Browser{}}
 	config.UserConfig.Browser = "three"
 	err := browserMustBeSupported(config)
 	if err == nil {
@@ -26,6 +27,74 @@ func TestBrowserMustBeSupportedFail(t *testing.T) {
 	}
 }
 
+func TestBrowsersMustBeValidPass(t *testing.T) {
+	config := mocks.NewConfig()
+	config.UserConfig.Browsers = map[string]*marks.Browser{
+		"w3m": &marks.Browser{Name: "w3m", Command: "w3m", Args: "{{.Url}}"},
+	}
+	err := browsersMustBeValid(config)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+}
+
+func TestBrowsersMustBeValidFail(t *testing.T) {
+	config := mocks.NewConfig()
+	config.UserConfig.Browsers = map[string]*marks.Browser{
+		"w3m": &marks.Browser{Name: "w3m", Args: "{{.Url}"},
+	}
+	err := browsersMustBeValid(config)
+	if err == nil {
+		t.Fatal("Should cause error")
+	}
+}
+
+func TestBrowsersMustBeValidFailWithUnusedProfile(t *testing.T) {
+	config := mocks.NewConfig()
+	config.UserConfig.Browsers = map[string]*marks.Browser{
+		"w3m": &marks.Browser{Name: "w3m", Command: "w3m", Args: "{{.Url}}", Profile: "Work"},
+	}
+	err := browsersMustBeValid(config)
+	if err == nil {
+		t.Fatal("Should cause error")
+	}
+}
+
+func TestRoutesMustBeValidPass(t *testing.T) {
+	config := mocks.NewConfig()
+	config.UserConfig.Browsers = map[string]*marks.Browser{"chrome": &marks.Browser{Command: launchCommands("linux")["chrome"]}}
+	config.UserConfig.Routes = []*marks.Route{
+		&marks.Route{Tag: "work", Browser: "chrome", Profile: "Work"},
+		&marks.Route{Host: "*.atlassian.net", Url: "^https://", Browser: "chrome"},
+	}
+	err := routesMustBeValid(config)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+}
+
+func TestRoutesMustBeValidFail(t *testing.T) {
+	routes := []*marks.Route{
+		&marks.Route{Browser: "chrome"},
+		&marks.Route{Tag: "work", Browser: "lynx"},
+		&marks.Route{Host: "[", Browser: "chrome"},
+		&marks.Route{Url: "(", Browser: "chrome"},
+		&marks.Route{Tag: "work", Browser: "firefox", Profile: "Work"},
+	}
+	for _, route := range routes {
+		config := mocks.NewConfig()
+		config.UserConfig.Browsers = map[string]*marks.Browser{
+			"chrome":  &marks.Browser{},
+			"firefox": &marks.Browser{Command: "firefox {{.Url}}"},
+		}
+		config.UserConfig.Routes = []*marks.Route{route}
+		err := routesMustBeValid(config)
+		if err == nil {
+			t.Fatalf("route %v should cause error", route)
+		}
+	}
+}
+
 func TestColorsMustBeSupportedPass(t *testing.T) {
 	config := mocks.NewConfig()
 	config.AppConfig.SupportedColors = []string{"one", "two"}
@@ -51,3 +120,63 @@ func TestColorsMustBeSupportedFail(t *testing.T) {
 		t.Fatal("Should cause error")
 	}
 }
+
+func TestOutputMustBeSupportedPass(t *testing.T) {
+	config := mocks.NewConfig()
+	config.AppConfig.SupportedOutputs = []string{"one", "two"}
+	config.UserConfig.Output = "two"
+	err := outputMustBeSupported(config)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+}
+
+func TestOutputMustBeSupportedFail(t *testing.T) {
+	config := mocks.NewConfig()
+	config.AppConfig.SupportedOutputs = []string{"one", "two"}
+	config.UserConfig.Output = "three"
+	err := outputMustBeSupported(config)
+	if err == nil {
+		t.Fatal("Should cause error")
+	}
+}
+
+func TestRankMustBeSupportedPass(t *testing.T) {
+	config := mocks.NewConfig()
+	config.AppConfig.SupportedRanks = []string{"one", "two"}
+	config.UserConfig.Rank = "two"
+	err := rankMustBeSupported(config)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+}
+
+func TestRankMustBeSupportedFail(t *testing.T) {
+	config := mocks.NewConfig()
+	config.AppConfig.SupportedRanks = []string{"one", "two"}
+	config.UserConfig.Rank = "three"
+	err := rankMustBeSupported(config)
+	if err == nil {
+		t.Fatal("Should cause error")
+	}
+}
+
+func TestTagAliasesMustBeCanonicalPass(t *testing.T) {
+	config := mocks.NewConfig()
+	config.UserConfig.NormalizeTags = true
+	config.UserConfig.TagWordSeparator = "-"
+	config.UserConfig.TagAliases = map[string]string{"k8s": "kubernetes", "kube": "Kubernetes"}
+	err := tagAliasesMustBeCanonical(config)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+}
+
+func TestTagAliasesMustBeCanonicalFail(t *testing.T) {
+	config := mocks.NewConfig()
+	config.UserConfig.TagAliases = map[string]string{"k8s": "kube", "kube": "kubernetes"}
+	err := tagAliasesMustBeCanonical(config)
+	if err == nil {
+		t.Fatal("Should cause error")
+	}
+}
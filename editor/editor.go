@@ -0,0 +1,63 @@
+package editor
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"os/exec"
+
+	"github.com/mattn/go-shellwords"
+)
+
+type editor struct {
+	command string
+}
+
+// NewEditor edits text with command, or when command is empty with
+// $VISUAL, $EDITOR or vi, in that order.
+func NewEditor(command string) *editor {
+	for _, c := range []string{command, os.Getenv("VISUAL"), os.Getenv("EDITOR")} {
+		if c != "" {
+			return &editor{c}
+		}
+	}
+	return &editor{"vi"}
+}
+
+// Edit writes text to a temporary file ending in ext, opens it in the
+// editor and returns the file's contents once the editor exits.
+func (e *editor) Edit(text, ext string) (string, error) {
+	file, err := ioutil.TempFile("", "marks-*"+ext)
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(file.Name())
+	if _, err := file.WriteString(text); err != nil {
+		file.Close()
+		return "", err
+	}
+	if err := file.Close(); err != nil {
+		return "", err
+	}
+
+	args, err := shellwords.Parse(e.command)
+	if err != nil {
+		return "", err
+	}
+	if len(args) == 0 {
+		return "", errors.New("no editor command")
+	}
+	cmd := exec.Command(args[0], append(args[1:], file.Name())...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return "", err
+	}
+
+	edited, err := ioutil.ReadFile(file.Name())
+	if err != nil {
+		return "", err
+	}
+	return string(edited), nil
+}
@@ -0,0 +1,36 @@
+package editor
+
+import (
+	"os"
+	"testing"
+)
+
+func TestEdit(t *testing.T) {
+	e := NewEditor(`sh -c 'test "$(cat "$1")" = original && printf edited > "$1"' sh`)
+	edited, err := e.Edit("original", ".txt")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if edited != "edited" {
+		t.Fatalf("expected edited, received %v", edited)
+	}
+}
+
+func TestEditFails(t *testing.T) {
+	e := NewEditor("false")
+	if _, err := e.Edit("original", ".txt"); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestNewEditorFallsBackToEnvironment(t *testing.T) {
+	os.Setenv("VISUAL", "")
+	os.Setenv("EDITOR", "nano")
+	defer os.Unsetenv("EDITOR")
+	if e := NewEditor(""); e.command != "nano" {
+		t.Fatalf("expected nano, received %v", e.command)
+	}
+	if e := NewEditor("code --wait"); e.command != "code --wait" {
+		t.Fatalf("expected code --wait, received %v", e.command)
+	}
+}
@@ -0,0 +1,21 @@
+package exporter
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/tomguerney/marks/marks"
+)
+
+type exporter interface {
+	Export([]*marks.Mark) ([]byte, error)
+}
+
+func NewExporter(format string, primary bool) (exporter, error) {
+	switch format {
+	case "netscape":
+		return NewNetscapeExporter(primary), nil
+	default:
+		return nil, errors.New(fmt.Sprintf("export format \"%v\" not supported", format))
+	}
+}
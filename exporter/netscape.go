@@ -0,0 +1,109 @@
+package exporter
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/tomguerney/marks/marks"
+)
+
+// netscape writes the Netscape bookmark file format that browsers accept
+// for import. By default every tag becomes a folder holding each mark with
+// that tag. With primary set, a mark is written once, into the folder named
+// by its first tag, and its remaining tags are kept in the TAGS attribute.
+type netscape struct {
+	primary bool
+}
+
+type folder struct {
+	name string
+	mks  []*marks.Mark
+}
+
+const netscapeHeader = `<!DOCTYPE NETSCAPE-Bookmark-file-1>
+<!-- This is an automatically generated file.
+     It will be read and overwritten.
+     DO NOT EDIT! -->
+<META HTTP-EQUIV="Content-Type" CONTENT="text/html; charset=UTF-8">
+<TITLE>Bookmarks</TITLE>
+<H1>Bookmarks</H1>
+`
+
+func NewNetscapeExporter(primary bool) *netscape {
+	return &netscape{primary}
+}
+
+func (n *netscape) Export(mks []*marks.Mark) ([]byte, error) {
+	folders, unfiled := n.fold(mks)
+	builder := strings.Builder{}
+	builder.WriteString(netscapeHeader)
+	builder.WriteString("<DL><p>\n")
+	for _, f := range folders {
+		fmt.Fprintf(&builder, "    <DT><H3>%v</H3>\n", html.EscapeString(f.name))
+		builder.WriteString("    <DL><p>\n")
+		for _, m := range f.mks {
+			n.writeLink(&builder, "        ", m, f.name)
+		}
+		builder.WriteString("    </DL><p>\n")
+	}
+	for _, m := range unfiled {
+		n.writeLink(&builder, "    ", m, "")
+	}
+	builder.WriteString("</DL><p>\n")
+	return []byte(builder.String()), nil
+}
+
+// fold groups marks into folders, in the order their tags are first seen.
+// Tags are grouped case-insensitively. Marks without tags are unfiled.
+func (n *netscape) fold(mks []*marks.Mark) (folders []*folder, unfiled []*marks.Mark) {
+	byName := map[string]*folder{}
+	for _, m := range mks {
+		tags := m.Tags
+		if n.primary && len(tags) > 0 {
+			tags = tags[:1]
+		}
+		if len(tags) == 0 {
+			unfiled = append(unfiled, m)
+			continue
+		}
+		for _, tag := range tags {
+			key := strings.ToLower(tag)
+			f, ok := byName[key]
+			if !ok {
+				f = &folder{name: tag}
+				byName[key] = f
+				folders = append(folders, f)
+			}
+			f.mks = append(f.mks, m)
+		}
+	}
+	return folders, unfiled
+}
+
+func (n *netscape) writeLink(builder *strings.Builder, indent string, m *marks.Mark, folderName string) {
+	attributes := []string{fmt.Sprintf("HREF=\"%v\"", html.EscapeString(m.Url))}
+	if !m.CreatedAt().IsZero() {
+		attributes = append(attributes, fmt.Sprintf("ADD_DATE=\"%v\"", m.CreatedAt().Unix()))
+	}
+	if tags := n.attributeTags(m, folderName); len(tags) > 0 {
+		attributes = append(attributes, fmt.Sprintf("TAGS=\"%v\"", html.EscapeString(strings.Join(tags, ","))))
+	}
+	fmt.Fprintf(builder, "%v<DT><A %v>%v</A>\n", indent, strings.Join(attributes, " "), html.EscapeString(m.Id))
+}
+
+// attributeTags returns the tags not already expressed by the folder that
+// the mark is written into. In the default mode every tag has its own
+// folder, so none are needed.
+func (n *netscape) attributeTags(m *marks.Mark, folderName string) []string {
+	if !n.primary {
+		return nil
+	}
+	tags := []string{}
+	for _, tag := range m.Tags {
+		if !strings.EqualFold(tag, folderName) {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
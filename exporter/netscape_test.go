@@ -0,0 +1,96 @@
+package exporter
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tomguerney/marks/importer"
+	"github.com/tomguerney/marks/marks"
+)
+
+var testMarks = []*marks.Mark{
+	&marks.Mark{
+		Id:      "Abc News",
+		Url:     "https://www.abc.net.au/news/",
+		Tags:    []string{"news", "current affairs"},
+		Created: marks.Timestamp(time.Unix(1600000000, 0).UTC()),
+	},
+	&marks.Mark{
+		Id:   "Google",
+		Url:  "https://www.google.com",
+		Tags: []string{},
+	},
+	&marks.Mark{
+		Id:   "BBC News & Sport",
+		Url:  "https://www.bbc.com/news",
+		Tags: []string{"News", "uk"},
+	},
+}
+
+func TestNetscapeExport(t *testing.T) {
+	actual, err := NewNetscapeExporter(false).Export(testMarks)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	expected := netscapeHeader + strings.Join([]string{
+		`<DL><p>`,
+		`    <DT><H3>news</H3>`,
+		`    <DL><p>`,
+		`        <DT><A HREF="https://www.abc.net.au/news/" ADD_DATE="1600000000">Abc News</A>`,
+		`        <DT><A HREF="https://www.bbc.com/news">BBC News &amp; Sport</A>`,
+		`    </DL><p>`,
+		`    <DT><H3>current affairs</H3>`,
+		`    <DL><p>`,
+		`        <DT><A HREF="https://www.abc.net.au/news/" ADD_DATE="1600000000">Abc News</A>`,
+		`    </DL><p>`,
+		`    <DT><H3>uk</H3>`,
+		`    <DL><p>`,
+		`        <DT><A HREF="https://www.bbc.com/news">BBC News &amp; Sport</A>`,
+		`    </DL><p>`,
+		`    <DT><A HREF="https://www.google.com">Google</A>`,
+		`</DL><p>`,
+	}, "\n") + "\n"
+	if string(actual) != expected {
+		t.Fatalf("expected\n%v\nreceived\n%v", expected, string(actual))
+	}
+}
+
+func TestNetscapeExportPrimary(t *testing.T) {
+	actual, err := NewNetscapeExporter(true).Export(testMarks)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	expected := netscapeHeader + strings.Join([]string{
+		`<DL><p>`,
+		`    <DT><H3>news</H3>`,
+		`    <DL><p>`,
+		`        <DT><A HREF="https://www.abc.net.au/news/" ADD_DATE="1600000000" TAGS="current affairs">Abc News</A>`,
+		`        <DT><A HREF="https://www.bbc.com/news" TAGS="uk">BBC News &amp; Sport</A>`,
+		`    </DL><p>`,
+		`    <DT><A HREF="https://www.google.com">Google</A>`,
+		`</DL><p>`,
+	}, "\n") + "\n"
+	if string(actual) != expected {
+		t.Fatalf("expected\n%v\nreceived\n%v", expected, string(actual))
+	}
+}
+
+func TestNetscapeExportRoundTrip(t *testing.T) {
+	exported, err := NewNetscapeExporter(true).Export(testMarks)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	imported, err := importer.NewNetscapeImporter("").Import(exported)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(imported) != len(testMarks) {
+		t.Fatalf("expected %v marks, received %v", len(testMarks), len(imported))
+	}
+	for _, m := range imported {
+		if m.Id == "BBC News & Sport" && len(m.Tags) != 2 {
+			t.Fatalf("expected 2 tags, received %v", m.Tags)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package fetcher
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/tomguerney/marks/marks"
+)
+
+// maxBytes bounds how much of a page is read looking for its head.
+const maxBytes = 1 << 20
+
+var (
+	titlePattern = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+	tagPattern   = regexp.MustCompile(`(?is)<(meta|link)\s[^>]*>`)
+	attrPattern  = regexp.MustCompile(`(?is)([a-z:-]+)\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s"'>]+))`)
+	headEnd      = regexp.MustCompile(`(?i)</head\s*>|<body[\s>]`)
+	spaces       = regexp.MustCompile(`\s+`)
+)
+
+type fetcher struct {
+	client *http.Client
+}
+
+func NewFetcher(timeout time.Duration) *fetcher {
+	return &fetcher{
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// Fetch downloads the page at rawUrl and reads its title, description,
+// canonical url and keywords, preferring Open Graph values where present.
+func (f *fetcher) Fetch(rawUrl string) (*marks.Page, error) {
+	req, err := http.NewRequest(http.MethodGet, rawUrl, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "text/html")
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return nil, errors.New(fmt.Sprintf("fetching %v returned %v", rawUrl, resp.Status))
+	}
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxBytes))
+	if err != nil {
+		return nil, err
+	}
+	page := Parse(string(body))
+	page.CanonicalUrl = resolve(resp.Request.URL, page.CanonicalUrl)
+	return page, nil
+}
+
+// Parse reads the metadata from the head of an html document.
+func Parse(document string) *marks.Page {
+	if loc := headEnd.FindStringIndex(document); loc != nil {
+		document = document[:loc[0]]
+	}
+	page := &marks.Page{}
+	if match := titlePattern.FindStringSubmatch(document); match != nil {
+		page.Title = clean(match[1])
+	}
+	var ogTitle, ogDescription, ogUrl string
+	for _, tag := range tagPattern.FindAllStringSubmatch(document, -1) {
+		attrs := attributes(tag[0])
+		if strings.EqualFold(tag[1], "link") {
+			if hasToken(attrs["rel"], "canonical") && page.CanonicalUrl == "" {
+				page.CanonicalUrl = html.UnescapeString(attrs["href"])
+			}
+			continue
+		}
+		content := clean(attrs["content"])
+		name := attrs["property"]
+		if name == "" {
+			name = attrs["name"]
+		}
+		switch strings.ToLower(name) {
+		case "og:title":
+			ogTitle = content
+		case "og:description":
+			ogDescription = content
+		case "og:url":
+			ogUrl = content
+		case "description":
+			page.Description = content
+		case "keywords":
+			page.Keywords = keywords(content)
+		}
+	}
+	if ogTitle != "" {
+		page.Title = ogTitle
+	}
+	if ogDescription != "" {
+		page.Description = ogDescription
+	}
+	if page.CanonicalUrl == "" {
+		page.CanonicalUrl = ogUrl
+	}
+	return page
+}
+
+func attributes(tag string) map[string]string {
+	attrs := map[string]string{}
+	for _, match := range attrPattern.FindAllStringSubmatch(tag, -1) {
+		attrs[strings.ToLower(match[1])] = match[2] + match[3] + match[4]
+	}
+	return attrs
+}
+
+func hasToken(list, token string) bool {
+	for _, t := range strings.Fields(list) {
+		if strings.EqualFold(t, token) {
+			return true
+		}
+	}
+	return false
+}
+
+func keywords(content string) []string {
+	kws := []string{}
+	seen := map[string]bool{}
+	for _, kw := range strings.Split(content, ",") {
+		kw = strings.TrimSpace(kw)
+		if kw != "" && !seen[strings.ToLower(kw)] {
+			seen[strings.ToLower(kw)] = true
+			kws = append(kws, kw)
+		}
+	}
+	return kws
+}
+
+func clean(s string) string {
+	return strings.TrimSpace(spaces.ReplaceAllString(html.UnescapeString(s), " "))
+}
+
+// resolve makes a canonical url relative to the page absolute, dropping
+// anything that is not http or https.
+func resolve(base *url.URL, ref string) string {
+	if ref == "" {
+		return ""
+	}
+	u, err := base.Parse(ref)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return ""
+	}
+	return u.String()
+}
@@ -0,0 +1,86 @@
+package fetcher
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/tomguerney/marks/marks"
+)
+
+const testPage = `<!DOCTYPE html>
+<html>
+<head>
+  <title>
+    Abc News &amp; Current Affairs
+  </title>
+  <meta charset="utf-8">
+  <meta name="description" content="Plain description">
+  <meta property="og:description" content='Open Graph &quot;description&quot;'>
+  <meta name="keywords" content="news, current affairs, News, ">
+  <link rel="alternate stylesheet" href="/style.css">
+  <link rel="canonical" href="/news?a=1&amp;b=2">
+</head>
+<body>
+  <title>Not the title</title>
+  <meta name="keywords" content="body">
+</body>
+</html>`
+
+func newTestServer() *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/page", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(testPage))
+	})
+	mux.HandleFunc("/missing", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		w.Write([]byte(testPage))
+	})
+	return httptest.NewServer(mux)
+}
+
+func TestFetch(t *testing.T) {
+	server := newTestServer()
+	defer server.Close()
+	page, err := NewFetcher(time.Second).Fetch(server.URL + "/page")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	expected := &marks.Page{
+		Title:        "Abc News & Current Affairs",
+		Description:  "Open Graph \"description\"",
+		CanonicalUrl: server.URL + "/news?a=1&b=2",
+		Keywords:     []string{"news", "current affairs"},
+	}
+	if !reflect.DeepEqual(page, expected) {
+		t.Fatalf("expected %v, received %v", expected, page)
+	}
+}
+
+func TestFetchMissing(t *testing.T) {
+	server := newTestServer()
+	defer server.Close()
+	if _, err := NewFetcher(time.Second).Fetch(server.URL + "/missing"); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestFetchTimeout(t *testing.T) {
+	server := newTestServer()
+	defer server.Close()
+	if _, err := NewFetcher(50 * time.Millisecond).Fetch(server.URL + "/slow"); err == nil {
+		t.Fatal("expected timeout")
+	}
+}
+
+func TestParseOpenGraphTitle(t *testing.T) {
+	page := Parse(`<head><title>Plain</title><meta property="og:title" content="Open Graph"><meta property="og:url" content="https://abc.net.au/"></head>`)
+	if page.Title != "Open Graph" || page.CanonicalUrl != "https://abc.net.au/" {
+		t.Fatalf("unexpected page %v", page)
+	}
+}
@@ -0,0 +1,203 @@
+// Package fuzzy matches user input against mark ids, urls and tags while
+// tolerating missing characters and small typos.
+package fuzzy
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/tomguerney/marks/marks"
+)
+
+const (
+	substringScore   = 1000
+	subsequenceScore = 500
+	typoScore        = 100
+)
+
+// Score reports whether pattern fuzzily matches text and, if so, how well.
+// Substring matches score highest, then subsequence matches ("abcnews" in
+// "Abc News"), then matches within a small edit distance of the text or
+// one of its words ("curent" for "current"). Matching ignores case.
+func Score(pattern, text string) (int, bool) {
+	pattern = strings.ToLower(pattern)
+	text = strings.ToLower(text)
+	if pattern == "" {
+		return 0, true
+	}
+	if strings.Contains(text, pattern) {
+		return substringScore - (len(text) - len(pattern)), true
+	}
+	if gaps, ok := subsequence(pattern, text); ok {
+		return subsequenceScore - gaps, true
+	}
+	distance := Distance(pattern, text)
+	for _, word := range strings.Fields(text) {
+		if d := Distance(pattern, word); d < distance {
+			distance = d
+		}
+	}
+	if distance <= allowedTypos(pattern) {
+		return typoScore - distance, true
+	}
+	return 0, false
+}
+
+// subsequence reports whether every character of pattern appears in text
+// in order, and how many characters of text were skipped between the
+// first and last matched characters.
+func subsequence(pattern, text string) (gaps int, ok bool) {
+	p := []rune(pattern)
+	i, first, last := 0, -1, -1
+	for j, r := range []rune(text) {
+		if i < len(p) && r == p[i] {
+			if first < 0 {
+				first = j
+			}
+			last = j
+			i++
+		}
+	}
+	if i < len(p) {
+		return 0, false
+	}
+	return last - first + 1 - len(p), true
+}
+
+func allowedTypos(pattern string) int {
+	n := len([]rune(pattern)) / 4
+	if n < 1 {
+		return 1
+	}
+	return n
+}
+
+// Distance returns the edit distance between a and b, counting insertions,
+// deletions, substitutions and transpositions of adjacent characters.
+func Distance(a, b string) int {
+	ar, br := []rune(a), []rune(b)
+	d := make([][]int, len(ar)+1)
+	for i := range d {
+		d[i] = make([]int, len(br)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(ar); i++ {
+		for j := 1; j <= len(br); j++ {
+			cost := 1
+			if ar[i-1] == br[j-1] {
+				cost = 0
+			}
+			d[i][j] = minimum(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && ar[i-1] == br[j-2] && ar[i-2] == br[j-1] {
+				d[i][j] = minimum(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(ar)][len(br)]
+}
+
+func minimum(values ...int) int {
+	m := values[0]
+	for _, v := range values[1:] {
+		if v < m {
+			m = v
+		}
+	}
+	return m
+}
+
+// ScoreTag is Score for hierarchical tags. The pattern is also scored
+// against each of the tag's ancestors, so that, as with exact matching, a
+// tag term matches the tag's descendants. An empty separator scores whole
+// tags only.
+func ScoreTag(pattern, tag, sep string) (int, bool) {
+	best, found := 0, false
+	for _, ancestor := range ancestors(tag, sep) {
+		if score, ok := Score(pattern, ancestor); ok && (!found || score > best) {
+			best, found = score, true
+		}
+	}
+	return best, found
+}
+
+// ancestors returns the tag and each of its ancestors, e.g. work,
+// work/projectx and work/projectx/ci for work/projectx/ci.
+func ancestors(tag, sep string) []string {
+	if sep == "" {
+		return []string{tag}
+	}
+	segments := strings.Split(tag, sep)
+	tags := []string{}
+	for i := 1; i <= len(segments); i++ {
+		tags = append(tags, strings.Join(segments[:i], sep))
+	}
+	return tags
+}
+
+// Filter returns the marks whose id, url and every tag fuzzily match, best
+// match first. Empty arguments match everything. Tags are compared as
+// ScoreTag does, after normalizing them if a normalizer is given.
+func Filter(mks []*marks.Mark, id, url string, tags []string, sep string, normalizer *marks.TagNormalizer) []*marks.Mark {
+	if normalizer != nil {
+		normalized := []string{}
+		for _, tag := range tags {
+			normalized = append(normalized, normalizer.Normalize(tag))
+		}
+		tags = normalized
+	}
+	type scored struct {
+		mark  *marks.Mark
+		score int
+	}
+	matches := []scored{}
+	for _, m := range mks {
+		if score, ok := scoreMark(m, id, url, tags, sep, normalizer); ok {
+			matches = append(matches, scored{m, score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
+	filtered := []*marks.Mark{}
+	for _, match := range matches {
+		filtered = append(filtered, match.mark)
+	}
+	return filtered
+}
+
+func scoreMark(m *marks.Mark, id, url string, tags []string, sep string, normalizer *marks.TagNormalizer) (int, bool) {
+	total := 0
+	if id != "" {
+		score, ok := Score(id, m.Id)
+		if !ok {
+			return 0, false
+		}
+		total += score
+	}
+	if url != "" {
+		score, ok := Score(url, m.Url)
+		if !ok {
+			return 0, false
+		}
+		total += score
+	}
+	for _, tag := range tags {
+		best, found := 0, false
+		for _, markTag := range m.Tags {
+			if normalizer != nil {
+				markTag = normalizer.Normalize(markTag)
+			}
+			if score, ok := ScoreTag(tag, markTag, sep); ok && (!found || score > best) {
+				best, found = score, true
+			}
+		}
+		if !found {
+			return 0, false
+		}
+		total += best
+	}
+	return total, true
+}
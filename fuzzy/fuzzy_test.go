@@ -0,0 +1,93 @@
+package fuzzy
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/tomguerney/marks/marks"
+	"github.com/tomguerney/marks/mocks"
+)
+
+func TestScoreMatches(t *testing.T) {
+	tests := []struct {
+		pattern string
+		text    string
+	}{
+		{"", "anything"},
+		{"news", "Abc News"},
+		{"abcnews", "Abc News"},
+		{"curent affairs", "current affairs"},
+		{"nwes", "Abc News"},
+	}
+	for _, test := range tests {
+		if _, ok := Score(test.pattern, test.text); !ok {
+			t.Fatalf("expected %v to match %v", test.pattern, test.text)
+		}
+	}
+}
+
+func TestScoreNoMatch(t *testing.T) {
+	if _, ok := Score("weather", "Abc News"); ok {
+		t.Fatal("expected no match")
+	}
+}
+
+func TestScoreOrdering(t *testing.T) {
+	substring, _ := Score("news", "Abc News")
+	subsequence, _ := Score("abcnews", "Abc News")
+	typo, _ := Score("nwes", "Abc News")
+	if !(substring > subsequence && subsequence > typo) {
+		t.Fatalf("expected %v > %v > %v", substring, subsequence, typo)
+	}
+}
+
+func TestDistance(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"kitten", "sitting", 3},
+		{"curent", "current", 1},
+		{"nwes", "news", 1},
+	}
+	for _, test := range tests {
+		if actual := Distance(test.a, test.b); actual != test.expected {
+			t.Fatalf("%v, %v: expected %v, received %v", test.a, test.b, test.expected, actual)
+		}
+	}
+}
+
+func TestFilter(t *testing.T) {
+	abc := &marks.Mark{Id: "Abc News", Url: "https://www.abc.net.au/news/", Tags: []string{"news", "current affairs"}}
+	abcd := &marks.Mark{Id: "Abcd Shop", Url: "https://abcd.com", Tags: []string{"shopping"}}
+	google := &marks.Mark{Id: "Google", Url: "https://www.google.com", Tags: []string{"search"}}
+	mks := []*marks.Mark{abcd, google, abc}
+	actual := Filter(mks, "abcnews", "", []string{"curent affairs"}, "/", nil)
+	expected := []*marks.Mark{abc}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %v, received %v", expected, actual)
+	}
+	actual = Filter(mks, "abc", "", nil, "/", nil)
+	expected = []*marks.Mark{abc, abcd}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %v, received %v", expected, actual)
+	}
+}
+
+func TestFilterTags(t *testing.T) {
+	helm := &marks.Mark{Id: "Helm", Tags: []string{"kubernetes/helm"}}
+	ci := &marks.Mark{Id: "CI", Tags: []string{"work/ci"}}
+	config := mocks.NewConfig()
+	config.TagSeparator = "/"
+	config.TagAliases = map[string]string{"k8s": "kubernetes"}
+	normalizer := marks.NewTagNormalizer(config)
+	for _, tag := range []string{"k8s", "kubernetes", "kubernets", "k8s/helm"} {
+		actual := Filter([]*marks.Mark{ci, helm}, "", "", []string{tag}, "/", normalizer)
+		expected := []*marks.Mark{helm}
+		if !reflect.DeepEqual(actual, expected) {
+			t.Fatalf("%v: expected %v, received %v", tag, expected, actual)
+		}
+	}
+}
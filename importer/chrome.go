@@ -0,0 +1,80 @@
+package importer
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/tomguerney/marks/marks"
+)
+
+// chrome parses the Bookmarks JSON file kept in a Chrome or Chromium
+// profile directory.
+type chrome struct {
+	tagPrefix string
+}
+
+type chromeFile struct {
+	Roots map[string]*chromeNode `json:"roots"`
+}
+
+type chromeNode struct {
+	Name      string        `json:"name"`
+	Type      string        `json:"type"`
+	Url       string        `json:"url"`
+	DateAdded string        `json:"date_added"`
+	Children  []*chromeNode `json:"children"`
+}
+
+// The order in which Chrome displays its root folders.
+var chromeRoots = []string{"bookmark_bar", "other", "synced"}
+
+// Chrome timestamps count microseconds from 1601-01-01 UTC.
+const chromeEpochOffset = 11644473600000000
+
+func NewChromeImporter(tagPrefix string) *chrome {
+	return &chrome{tagPrefix}
+}
+
+func (c *chrome) Import(data []byte) ([]*marks.Mark, error) {
+	file := &chromeFile{}
+	if err := json.Unmarshal(data, file); err != nil {
+		return nil, err
+	}
+	mks := []*marks.Mark{}
+	for _, name := range chromeRoots {
+		if root, ok := file.Roots[name]; ok && root != nil {
+			mks = c.walk(root.Children, []string{}, mks)
+		}
+	}
+	return mks, nil
+}
+
+func (c *chrome) walk(nodes []*chromeNode, folders []string, mks []*marks.Mark) []*marks.Mark {
+	for _, node := range nodes {
+		switch node.Type {
+		case "folder":
+			mks = c.walk(node.Children, append(folders[:len(folders):len(folders)], node.Name), mks)
+		case "url":
+			id := node.Name
+			if id == "" {
+				id = node.Url
+			}
+			mks = append(mks, &marks.Mark{
+				Id:      id,
+				Url:     node.Url,
+				Tags:    folderTags(folders, c.tagPrefix),
+				Created: marks.Timestamp(chromeTime(node.DateAdded)),
+			})
+		}
+	}
+	return mks
+}
+
+func chromeTime(s string) time.Time {
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || n <= chromeEpochOffset {
+		return time.Time{}
+	}
+	return unixTime(n-chromeEpochOffset, time.Microsecond)
+}
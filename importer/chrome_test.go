@@ -0,0 +1,50 @@
+package importer
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/tomguerney/marks/marks"
+)
+
+func TestChromeImport(t *testing.T) {
+	data, err := ioutil.ReadFile(filepath.Join("testdata", "chrome_bookmarks.json"))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	actual, err := NewChromeImporter("chrome/").Import(data)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	expected := []*marks.Mark{
+		&marks.Mark{
+			Id:      "Google",
+			Url:     "https://www.google.com/",
+			Tags:    []string{},
+			Created: marks.Timestamp(time.Date(2020, 9, 19, 14, 40, 0, 0, time.UTC)),
+		},
+		&marks.Mark{
+			Id:      "Abc News",
+			Url:     "https://www.abc.net.au/news/",
+			Tags:    []string{"chrome/News"},
+			Created: marks.Timestamp(time.Date(2020, 9, 19, 14, 41, 40, 0, time.UTC)),
+		},
+		&marks.Mark{
+			Id:   "https://www.bbc.com/news",
+			Url:  "https://www.bbc.com/news",
+			Tags: []string{},
+		},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected\n%v\nreceived\n%v", expected, actual)
+	}
+}
+
+func TestChromeImportInvalidJson(t *testing.T) {
+	if _, err := NewChromeImporter("").Import([]byte("{")); err == nil {
+		t.Fatal("should return error")
+	}
+}
@@ -0,0 +1,106 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/tomguerney/marks/marks"
+)
+
+// firefox parses the backups Firefox keeps in its profile's
+// bookmarkbackups directory, either as plain .json or as the
+// lz4-compressed .jsonlz4 files it writes by default.
+type firefox struct {
+	tagPrefix string
+}
+
+type firefoxNode struct {
+	Title     string         `json:"title"`
+	Type      string         `json:"type"`
+	Uri       string         `json:"uri"`
+	Root      string         `json:"root"`
+	DateAdded int64          `json:"dateAdded"`
+	Tags      string         `json:"tags"`
+	Children  []*firefoxNode `json:"children"`
+}
+
+const (
+	firefoxContainer = "text/x-moz-place-container"
+	firefoxPlace     = "text/x-moz-place"
+)
+
+var mozLz4Magic = []byte("mozLz40\x00")
+
+func NewFirefoxImporter(tagPrefix string) *firefox {
+	return &firefox{tagPrefix}
+}
+
+func (f *firefox) Import(data []byte) ([]*marks.Mark, error) {
+	if bytes.HasPrefix(data, mozLz4Magic) {
+		decompressed, err := decompressMozLz4(data)
+		if err != nil {
+			return nil, err
+		}
+		data = decompressed
+	}
+	root := &firefoxNode{}
+	if err := json.Unmarshal(data, root); err != nil {
+		return nil, err
+	}
+	return f.walk(root.Children, []string{}, []*marks.Mark{}), nil
+}
+
+func (f *firefox) walk(nodes []*firefoxNode, folders []string, mks []*marks.Mark) []*marks.Mark {
+	for _, node := range nodes {
+		switch node.Type {
+		case firefoxContainer:
+			// Built-in folders such as the toolbar and menu are marked with
+			// a root name and do not become tags.
+			children := folders
+			if node.Root == "" {
+				children = append(folders[:len(folders):len(folders)], node.Title)
+			}
+			mks = f.walk(node.Children, children, mks)
+		case firefoxPlace:
+			if strings.HasPrefix(node.Uri, "place:") {
+				continue
+			}
+			mks = append(mks, f.mark(node, folders))
+		}
+	}
+	return mks
+}
+
+func (f *firefox) mark(node *firefoxNode, folders []string) *marks.Mark {
+	id := node.Title
+	if id == "" {
+		id = node.Uri
+	}
+	tags := folderTags(folders, f.tagPrefix)
+	for _, tag := range strings.Split(node.Tags, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = appendTag(tags, tag)
+		}
+	}
+	m := &marks.Mark{Id: id, Url: node.Uri, Tags: tags}
+	if node.DateAdded > 0 {
+		m.Created = marks.Timestamp(unixTime(node.DateAdded, time.Microsecond))
+	}
+	return m
+}
+
+// decompressMozLz4 unwraps Mozilla's lz4 container: an 8 byte magic
+// number, the decompressed size as a little-endian uint32, then a single
+// lz4 block.
+func decompressMozLz4(data []byte) ([]byte, error) {
+	header := len(mozLz4Magic) + 4
+	if len(data) < header {
+		return nil, errors.New("mozlz4 file is truncated")
+	}
+	size := binary.LittleEndian.Uint32(data[len(mozLz4Magic):header])
+	return decompressLz4Block(data[header:], int(size))
+}
@@ -0,0 +1,79 @@
+package importer
+
+import (
+	"encoding/binary"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/tomguerney/marks/marks"
+)
+
+var expectedFirefoxMarks = []*marks.Mark{
+	&marks.Mark{
+		Id:      "Abc News",
+		Url:     "https://www.abc.net.au/news/",
+		Tags:    []string{"News", "current affairs"},
+		Created: marks.Timestamp(time.Unix(1600000100, 0).UTC()),
+	},
+	&marks.Mark{
+		Id:      "Google",
+		Url:     "https://www.google.com/",
+		Tags:    []string{},
+		Created: marks.Timestamp(time.Unix(1600000000, 0).UTC()),
+	},
+}
+
+func readFirefoxTestData(t *testing.T) []byte {
+	data, err := ioutil.ReadFile(filepath.Join("testdata", "firefox_bookmarks.json"))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	return data
+}
+
+// compressMozLz4 wraps data in a mozlz4 container holding a single
+// literal-only lz4 sequence, which is enough to exercise the decoder.
+func compressMozLz4(data []byte) []byte {
+	out := append([]byte{}, mozLz4Magic...)
+	size := make([]byte, 4)
+	binary.LittleEndian.PutUint32(size, uint32(len(data)))
+	out = append(out, size...)
+	out = append(out, 0xf0)
+	for n := len(data) - 15; ; n -= 255 {
+		if n < 255 {
+			out = append(out, byte(n))
+			break
+		}
+		out = append(out, 255)
+	}
+	return append(out, data...)
+}
+
+func TestFirefoxImport(t *testing.T) {
+	actual, err := NewFirefoxImporter("").Import(readFirefoxTestData(t))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if !reflect.DeepEqual(actual, expectedFirefoxMarks) {
+		t.Fatalf("expected\n%v\nreceived\n%v", expectedFirefoxMarks, actual)
+	}
+}
+
+func TestFirefoxImportLz4(t *testing.T) {
+	actual, err := NewFirefoxImporter("").Import(compressMozLz4(readFirefoxTestData(t)))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if !reflect.DeepEqual(actual, expectedFirefoxMarks) {
+		t.Fatalf("expected\n%v\nreceived\n%v", expectedFirefoxMarks, actual)
+	}
+}
+
+func TestFirefoxImportTruncatedLz4(t *testing.T) {
+	if _, err := NewFirefoxImporter("").Import(mozLz4Magic); err == nil {
+		t.Fatal("should return error")
+	}
+}
@@ -0,0 +1,63 @@
+package importer
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tomguerney/marks/marks"
+)
+
+type importer interface {
+	Import([]byte) ([]*marks.Mark, error)
+}
+
+// NewImporter returns the importer for format. Folders in the imported
+// file become tags, prefixed with tagPrefix.
+func NewImporter(format, tagPrefix string) (importer, error) {
+	switch format {
+	case "netscape":
+		return NewNetscapeImporter(tagPrefix), nil
+	case "chrome":
+		return NewChromeImporter(tagPrefix), nil
+	case "firefox":
+		return NewFirefoxImporter(tagPrefix), nil
+	default:
+		return nil, errors.New(fmt.Sprintf("import format \"%v\" not supported", format))
+	}
+}
+
+func folderTags(folders []string, tagPrefix string) []string {
+	tags := []string{}
+	for _, folder := range folders {
+		if folder != "" {
+			tags = appendTag(tags, tagPrefix+folder)
+		}
+	}
+	return tags
+}
+
+func appendTag(tags []string, tag string) []string {
+	for _, existing := range tags {
+		if strings.EqualFold(existing, tag) {
+			return tags
+		}
+	}
+	return append(tags, tag)
+}
+
+// parseTime parses a timestamp counted in units since the unix epoch.
+func parseTime(s string, unit time.Duration) time.Time {
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || n <= 0 {
+		return time.Time{}
+	}
+	return unixTime(n, unit)
+}
+
+func unixTime(n int64, unit time.Duration) time.Time {
+	perSecond := int64(time.Second / unit)
+	return time.Unix(n/perSecond, (n%perSecond)*int64(unit)).UTC()
+}
@@ -0,0 +1,87 @@
+package importer
+
+import "errors"
+
+var errCorruptLz4 = errors.New("lz4 block is corrupt")
+
+// maxLz4Ratio bounds how many times larger than the block its decompressed
+// size may be. Each byte of a block can stand for at most 255 bytes of
+// output, so a larger size is a corrupt header rather than one to allocate.
+const maxLz4Ratio = 255
+
+// decompressLz4Block decodes a raw lz4 block of size bytes once
+// decompressed, as described in
+// https://github.com/lz4/lz4/blob/dev/doc/lz4_Block_format.md
+func decompressLz4Block(src []byte, size int) ([]byte, error) {
+	if size < 0 || size > len(src)*maxLz4Ratio {
+		return nil, errCorruptLz4
+	}
+	dst := make([]byte, 0, size)
+	i := 0
+	for i < len(src) {
+		token := src[i]
+		i++
+
+		literals, n, err := lz4Length(src[i:], int(token>>4))
+		if err != nil {
+			return nil, err
+		}
+		i += n
+		if i+literals > len(src) || len(dst)+literals > size {
+			return nil, errCorruptLz4
+		}
+		dst = append(dst, src[i:i+literals]...)
+		i += literals
+
+		// The last sequence in a block holds only literals.
+		if i == len(src) {
+			break
+		}
+
+		if i+2 > len(src) {
+			return nil, errCorruptLz4
+		}
+		offset := int(src[i]) | int(src[i+1])<<8
+		i += 2
+		if offset == 0 || offset > len(dst) {
+			return nil, errCorruptLz4
+		}
+
+		match, n, err := lz4Length(src[i:], int(token&0x0f))
+		if err != nil {
+			return nil, err
+		}
+		i += n
+		match += 4
+		if len(dst)+match > size {
+			return nil, errCorruptLz4
+		}
+
+		// Matches may overlap the bytes they produce, so copy one at a time.
+		start := len(dst) - offset
+		for k := 0; k < match; k++ {
+			dst = append(dst, dst[start+k])
+		}
+	}
+	return dst, nil
+}
+
+// lz4Length reads the optional extension bytes that follow a 4 bit length
+// of 15, returning the full length and the number of bytes consumed.
+func lz4Length(src []byte, length int) (int, int, error) {
+	if length != 15 {
+		return length, 0, nil
+	}
+	n := 0
+	for {
+		if n >= len(src) {
+			return 0, 0, errCorruptLz4
+		}
+		b := src[n]
+		n++
+		length += int(b)
+		if b != 255 {
+			return length, n, nil
+		}
+	}
+}
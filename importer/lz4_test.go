@@ -0,0 +1,43 @@
+package importer
+
+import "testing"
+
+func TestDecompressLz4BlockWithMatch(t *testing.T) {
+	block := []byte{0x32, 'a', 'b', 'c', 0x03, 0x00, 0x00}
+	actual, err := decompressLz4Block(block, 9)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	expected := "abcabcabc"
+	if string(actual) != expected {
+		t.Fatalf("expected %v, received %v", expected, string(actual))
+	}
+}
+
+func TestDecompressLz4BlockWithBadOffset(t *testing.T) {
+	block := []byte{0x10, 'a', 0x05, 0x00, 0x00}
+	if _, err := decompressLz4Block(block, 5); err == nil {
+		t.Fatal("should return error")
+	}
+}
+
+func TestDecompressLz4BlockTruncated(t *testing.T) {
+	block := []byte{0x50, 'a', 'b'}
+	if _, err := decompressLz4Block(block, 5); err == nil {
+		t.Fatal("should return error")
+	}
+}
+
+func TestDecompressLz4BlockWithOversizedHeader(t *testing.T) {
+	block := []byte{0x30, 'a', 'b', 'c'}
+	if _, err := decompressLz4Block(block, 1<<31-1); err == nil {
+		t.Fatal("should return error")
+	}
+}
+
+func TestDecompressLz4BlockLargerThanHeader(t *testing.T) {
+	block := []byte{0x32, 'a', 'b', 'c', 0x03, 0x00, 0x00}
+	if _, err := decompressLz4Block(block, 6); err == nil {
+		t.Fatal("should return error")
+	}
+}
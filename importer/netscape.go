@@ -0,0 +1,105 @@
+package importer
+
+import (
+	"html"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/tomguerney/marks/marks"
+)
+
+// netscape parses the Netscape bookmark file format exported by every
+// major browser. The format is loosely structured HTML, so rather than
+// building a DOM it walks the tags in order, tracking the folder (<H3>)
+// that each nested list (<DL>) belongs to.
+type netscape struct {
+	tagPrefix string
+}
+
+var (
+	tagPattern       = regexp.MustCompile(`(?is)<(/?)([a-z0-9]+)([^>]*)>`)
+	attributePattern = regexp.MustCompile(`(?is)([a-z_]+)\s*=\s*"([^"]*)"`)
+)
+
+// Browsers export their built-in root folders (the bookmarks bar, unsorted
+// bookmarks) as ordinary folders flagged with one of these attributes.
+var rootFolderAttributes = []string{"personal_toolbar_folder", "unfiled_bookmarks_folder"}
+
+func NewNetscapeImporter(tagPrefix string) *netscape {
+	return &netscape{tagPrefix}
+}
+
+func (n *netscape) Import(data []byte) ([]*marks.Mark, error) {
+	doc := string(data)
+	mks := []*marks.Mark{}
+	folders := []string{}
+	pendingFolder := ""
+	rootFolder := false
+	var link *marks.Mark
+	textStart := 0
+
+	for _, loc := range tagPattern.FindAllStringSubmatchIndex(doc, -1) {
+		closing := doc[loc[2]:loc[3]] == "/"
+		name := strings.ToLower(doc[loc[4]:loc[5]])
+		attributes := parseAttributes(doc[loc[6]:loc[7]])
+		text := strings.TrimSpace(html.UnescapeString(doc[textStart:loc[0]]))
+		textStart = loc[1]
+
+		switch {
+		case name == "h3" && !closing:
+			pendingFolder = ""
+			rootFolder = isRootFolder(attributes)
+		case name == "h3" && closing && !rootFolder:
+			pendingFolder = text
+		case name == "dl" && !closing:
+			folders = append(folders, pendingFolder)
+			pendingFolder = ""
+		case name == "dl" && closing:
+			if len(folders) > 0 {
+				folders = folders[:len(folders)-1]
+			}
+		case name == "a" && !closing:
+			link = n.newLink(attributes, folders)
+		case name == "a" && closing && link != nil:
+			link.Id = text
+			if link.Id == "" {
+				link.Id = link.Url
+			}
+			mks = append(mks, link)
+			link = nil
+		}
+	}
+	return mks, nil
+}
+
+func (n *netscape) newLink(attributes map[string]string, folders []string) *marks.Mark {
+	tags := folderTags(folders, n.tagPrefix)
+	for _, tag := range strings.Split(attributes["tags"], ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = appendTag(tags, tag)
+		}
+	}
+	return &marks.Mark{
+		Url:     attributes["href"],
+		Tags:    tags,
+		Created: marks.Timestamp(parseTime(attributes["add_date"], time.Second)),
+	}
+}
+
+func parseAttributes(raw string) map[string]string {
+	attributes := map[string]string{}
+	for _, match := range attributePattern.FindAllStringSubmatch(raw, -1) {
+		attributes[strings.ToLower(match[1])] = html.UnescapeString(match[2])
+	}
+	return attributes
+}
+
+func isRootFolder(attributes map[string]string) bool {
+	for _, attribute := range rootFolderAttributes {
+		if _, ok := attributes[attribute]; ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,59 @@
+package importer
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/tomguerney/marks/marks"
+)
+
+func TestNetscapeImport(t *testing.T) {
+	data, err := ioutil.ReadFile(filepath.Join("testdata", "bookmarks.html"))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	actual, err := NewNetscapeImporter("").Import(data)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	expected := []*marks.Mark{
+		&marks.Mark{
+			Id:      "Google",
+			Url:     "https://www.google.com/",
+			Tags:    []string{},
+			Created: marks.Timestamp(time.Unix(1600000000, 0).UTC()),
+		},
+		&marks.Mark{
+			Id:      "Abc News",
+			Url:     "https://www.abc.net.au/news/",
+			Tags:    []string{"News", "current affairs"},
+			Created: marks.Timestamp(time.Unix(1600000100, 0).UTC()),
+		},
+		&marks.Mark{
+			Id:   "BBC News & Sport",
+			Url:  "https://www.bbc.com/news",
+			Tags: []string{"News", "UK"},
+		},
+		&marks.Mark{
+			Id:   "https://www.littlebird.com.au/",
+			Url:  "https://www.littlebird.com.au/",
+			Tags: []string{"Electronics"},
+		},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected\n%v\nreceived\n%v", expected, actual)
+	}
+}
+
+func TestNetscapeImportEmpty(t *testing.T) {
+	actual, err := NewNetscapeImporter("").Import([]byte{})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(actual) != 0 {
+		t.Fatalf("expected zero length slice, received %v", len(actual))
+	}
+}
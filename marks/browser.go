@@ -0,0 +1,55 @@
+package marks
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"text/template"
+
+	"github.com/mattn/go-shellwords"
+)
+
+// Browser is a program that bookmarks are opened with. Its command and args
+// are split like a shell command line and may refer to {{.Url}} and
+// {{.Profile}}.
+type Browser struct {
+	Name    string `json:"name"`
+	Command string `json:"command"`
+	Args    string `json:"args"`
+	Profile string `json:"profile"`
+}
+
+// UsesProfile reports whether the browser's command line refers to
+// {{.Profile}}, without which it cannot be opened in a profile.
+func (b *Browser) UsesProfile() bool {
+	return strings.Contains(b.CommandLine(), ".Profile")
+}
+
+// CommandLine is the command followed by its args.
+func (b *Browser) CommandLine() string {
+	return strings.TrimSpace(b.Command + " " + b.Args)
+}
+
+// Validate checks that the command line has a command and that each of its
+// arguments can be filled in with a url and the browser's profile.
+func (b *Browser) Validate() error {
+	args, err := shellwords.Parse(b.CommandLine())
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		return errors.New(fmt.Sprintf("browser \"%v\" has no command to open it", b.Name))
+	}
+	data := struct{ Url, Profile string }{Url: "https://example.com", Profile: b.Profile}
+	for _, arg := range args {
+		tmpl, err := template.New("open").Parse(arg)
+		if err != nil {
+			return err
+		}
+		if err := tmpl.Execute(ioutil.Discard, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,21 @@
+package marks
+
+import "testing"
+
+func TestBrowserValidate(t *testing.T) {
+	for _, test := range []struct {
+		browser *Browser
+		valid   bool
+	}{
+		{&Browser{Command: "lynx", Args: "{{.Url}}"}, true},
+		{&Browser{Command: "brave", Args: "--profile-directory={{.Profile}} {{.Url}}"}, true},
+		{&Browser{Args: "{{.Url}"}, false},
+		{&Browser{Command: "lynx", Args: "{{.Address}}"}, false},
+		{&Browser{Command: "lynx 'unclosed"}, false},
+		{&Browser{}, false},
+	} {
+		if err := test.browser.Validate(); (err == nil) != test.valid {
+			t.Fatalf("expected %v to be valid: %v, received %v", test.browser, test.valid, err)
+		}
+	}
+}
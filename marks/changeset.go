@@ -0,0 +1,15 @@
+package marks
+
+// Changeset is a set of creates, updates and deletes applied together.
+// Updates are keyed by the id the mark had before, so an update may also
+// rename it.
+type Changeset struct {
+	Creates []*Mark
+	Updates map[string]*Mark
+	Deletes []string
+}
+
+// Empty reports whether the changeset changes nothing.
+func (c *Changeset) Empty() bool {
+	return len(c.Creates) == 0 && len(c.Updates) == 0 && len(c.Deletes) == 0
+}
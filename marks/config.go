@@ -8,18 +8,32 @@ type Config struct {
 type AppConfig struct {
 	FullFormat        []string
 	MarksYamlFileMode uint32
-	SupportedBrowsers []string
 	SupportedColors   []string
+	SupportedOutputs  []string
+	SupportedRanks    []string
 }
 
 type UserConfig struct {
-	ContentPath     string
-	MarksYamlFile   string
-	IdColor         string
-	UrlColor        string
-	TagsColor       string
-	BrowserColor    string
-	ChromeOpenArgs  string
-	FirefoxOpenArgs string
-	Browser         string
+	ContentPath      string
+	MarksYamlFile    string
+	SearchesYamlFile string
+	SessionsYamlFile string
+	IdColor          string
+	UrlColor         string
+	TagsColor        string
+	BrowserColor     string
+	Browsers         map[string]*Browser
+	Routes           []*Route
+	OpenThreshold    int
+	Browser          string
+	Output           string
+	Format           string
+	ShowTimestamps   bool
+	Rank             string
+	Fuzzy            bool
+	TagSeparator     string
+	NormalizeTags    bool
+	TagWordSeparator string
+	TagAliases       map[string]string
+	Editor           string
 }
@@ -0,0 +1,5 @@
+package marks
+
+type Editor interface {
+	Edit(text, ext string) (string, error)
+}
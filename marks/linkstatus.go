@@ -0,0 +1,19 @@
+package marks
+
+// LinkStatus is the result of requesting a mark's url.
+type LinkStatus struct {
+	Mark       *Mark
+	StatusCode int
+	// Location is the url the request finally resolved to, if the
+	// server redirected it.
+	Location string
+	Err      error
+}
+
+func (s *LinkStatus) Broken() bool {
+	return s.Err != nil || s.StatusCode >= 400
+}
+
+func (s *LinkStatus) Redirected() bool {
+	return s.Location != "" && s.Location != s.Mark.Url
+}
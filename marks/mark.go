@@ -3,12 +3,61 @@ package marks
 import (
 	"fmt"
 	"strings"
+	"time"
 )
 
 type Mark struct {
-	Id   string
-	Url  string
-	Tags []string
+	Id          string     `json:"id"`
+	Url         string     `json:"url"`
+	Tags        []string   `json:"tags"`
+	Created     *time.Time `json:"created,omitempty" yaml:"created,omitempty"`
+	Updated     *time.Time `json:"updated,omitempty" yaml:"updated,omitempty"`
+	LastOpened  *time.Time `json:"lastOpened,omitempty" yaml:"lastOpened,omitempty"`
+	Visits      int        `json:"visits,omitempty" yaml:"visits,omitempty"`
+	Description string     `json:"description,omitempty" yaml:"description,omitempty"`
+	Notes       string     `json:"notes,omitempty" yaml:"notes,omitempty"`
+	Browser     string     `json:"browser,omitempty" yaml:"browser,omitempty"`
+}
+
+// Timestamp returns t for one of a mark's timestamps, or nil if t is zero so
+// that the timestamp is left out when the mark is written.
+func Timestamp(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+// CreatedAt returns when the mark was created, or the zero time if unknown.
+func (m *Mark) CreatedAt() time.Time {
+	return timeOf(m.Created)
+}
+
+// UpdatedAt returns when the mark was last updated, or the zero time if never.
+func (m *Mark) UpdatedAt() time.Time {
+	return timeOf(m.Updated)
+}
+
+// LastOpenedAt returns when the mark was last opened, or the zero time if
+// never.
+func (m *Mark) LastOpenedAt() time.Time {
+	return timeOf(m.LastOpened)
+}
+
+func timeOf(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return *t
+}
+
+// Copy returns a copy of the mark that shares no state with the original.
+func (m *Mark) Copy() *Mark {
+	c := *m
+	if m.Tags != nil {
+		c.Tags = append([]string{}, m.Tags...)
+	}
+	return &c
 }
 
 func (m *Mark) ContainsAllTags(subtags []string) bool {
@@ -1,6 +1,7 @@
 package marks
 
 import (
+	"reflect"
 	"testing"
 )
 
@@ -15,7 +16,7 @@ func newTestMark() *Mark {
 	return &Mark{
 		Id:   mockId,
 		Url:  mockUrl,
-		Tags: mockTags,
+		Tags: append([]string{}, mockTags...),
 	}
 }
 
@@ -49,3 +50,15 @@ func TestMarkDoesNotContainsAllTags(t *testing.T) {
 		t.Fatalf("mockMark contains tags %v", shouldNotContain)
 	}
 }
+
+func TestCopy(t *testing.T) {
+	mockMark := newTestMark()
+	c := mockMark.Copy()
+	if !reflect.DeepEqual(c, mockMark) {
+		t.Fatalf("expected %v, received %v", mockMark, c)
+	}
+	c.Tags[0] = "changed"
+	if mockMark.Tags[0] == "changed" {
+		t.Fatal("copy shares tags with original")
+	}
+}
@@ -4,10 +4,14 @@ type MarkService interface {
 	Mark(id string) (*Mark, error)
 	Marks() ([]*Mark, error)
 	Create(m *Mark) error
+	CreateAll(mks []*Mark) error
 	Update(id string, m *Mark) error
+	UpdateAll(updates map[string]*Mark) error
 	Delete(id string) error
+	Apply(c *Changeset) error
 	Contains(id string) (bool, error)
 	Filter(id, url string, tags []string) ([]*Mark, error)
+	Search(expr string) ([]*Mark, error)
 }
 
 type MarkAlreadyExistsError struct{}
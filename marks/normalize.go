@@ -0,0 +1,102 @@
+package marks
+
+import (
+	"regexp"
+	"strings"
+)
+
+var wordBreaks = regexp.MustCompile(`[\s_-]+`)
+
+// TagNormalizer rewrites tags into their canonical form. Tags are always
+// trimmed. When folding, they are also lower cased and runs of whitespace,
+// dashes and underscores become a single word separator. Aliases then
+// replace a whole tag, or the top of its hierarchy, with another.
+type TagNormalizer struct {
+	fold      bool
+	words     string
+	separator string
+	aliases   map[string]string
+}
+
+func NewTagNormalizer(c *Config) *TagNormalizer {
+	n := &TagNormalizer{
+		fold:      c.NormalizeTags,
+		words:     c.TagWordSeparator,
+		separator: c.TagSeparator,
+		aliases:   map[string]string{},
+	}
+	for from, to := range c.TagAliases {
+		n.aliases[strings.ToLower(n.canonical(from))] = n.canonical(to)
+	}
+	return n
+}
+
+// Normalize returns the canonical form of tag.
+func (n *TagNormalizer) Normalize(tag string) string {
+	tag = n.canonical(tag)
+	segments := []string{tag}
+	if n.separator != "" {
+		segments = strings.Split(tag, n.separator)
+	}
+	for i := len(segments); i > 0; i-- {
+		prefix := strings.Join(segments[:i], n.separator)
+		if to, ok := n.aliases[strings.ToLower(prefix)]; ok {
+			return to + tag[len(prefix):]
+		}
+	}
+	return tag
+}
+
+// NormalizeAll normalizes each tag, dropping empty tags and any that
+// become duplicates.
+func (n *TagNormalizer) NormalizeAll(tags []string) []string {
+	normalized := []string{}
+	seen := map[string]bool{}
+	for _, tag := range tags {
+		tag = n.Normalize(tag)
+		if tag == "" || seen[strings.ToLower(tag)] {
+			continue
+		}
+		seen[strings.ToLower(tag)] = true
+		normalized = append(normalized, tag)
+	}
+	return normalized
+}
+
+// NormalizeTags normalizes the mark's tags and reports whether they changed.
+func (m *Mark) NormalizeTags(n *TagNormalizer) bool {
+	normalized := n.NormalizeAll(m.Tags)
+	if sameTags(normalized, m.Tags) {
+		return false
+	}
+	m.Tags = normalized
+	return true
+}
+
+func (n *TagNormalizer) canonical(tag string) string {
+	segments := []string{tag}
+	if n.separator != "" {
+		segments = strings.Split(tag, n.separator)
+	}
+	for i, segment := range segments {
+		segment = strings.TrimSpace(segment)
+		if n.fold {
+			segment = strings.ToLower(segment)
+			segment = strings.Trim(wordBreaks.ReplaceAllString(segment, n.words), n.words)
+		}
+		segments[i] = segment
+	}
+	return strings.Join(segments, n.separator)
+}
+
+func sameTags(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
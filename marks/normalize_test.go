@@ -0,0 +1,56 @@
+package marks
+
+import (
+	"reflect"
+	"testing"
+)
+
+func newTestNormalizer(fold bool) *TagNormalizer {
+	return NewTagNormalizer(&Config{
+		AppConfig: &AppConfig{},
+		UserConfig: &UserConfig{
+			NormalizeTags:    fold,
+			TagWordSeparator: "-",
+			TagSeparator:     "/",
+			TagAliases:       map[string]string{"k8s": "kubernetes", "Kube": "kubernetes", "js": "javascript"},
+		},
+	})
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		tag      string
+		fold     bool
+		expected string
+	}{
+		{"  news ", true, "news"},
+		{"Current  Affairs", true, "current-affairs"},
+		{"current_affairs", true, "current-affairs"},
+		{"-current -- affairs-", true, "current-affairs"},
+		{"Work / ProjectX", true, "work/projectx"},
+		{"K8S", true, "kubernetes"},
+		{"kube/ci", true, "kubernetes/ci"},
+		{"k8sish", true, "k8sish"},
+		{"Current Affairs", false, "Current Affairs"},
+		{" K8s ", false, "kubernetes"},
+	}
+	for _, test := range tests {
+		if actual := newTestNormalizer(test.fold).Normalize(test.tag); actual != test.expected {
+			t.Fatalf("%q: expected %q, received %q", test.tag, test.expected, actual)
+		}
+	}
+}
+
+func TestNormalizeTags(t *testing.T) {
+	m := &Mark{Tags: []string{"k8s", "Kubernetes", "kube", " ", "JS"}}
+	if !m.NormalizeTags(newTestNormalizer(true)) {
+		t.Fatal("expected tags to change")
+	}
+	expected := []string{"kubernetes", "javascript"}
+	if !reflect.DeepEqual(m.Tags, expected) {
+		t.Fatalf("expected %v, received %v", expected, m.Tags)
+	}
+	if m.NormalizeTags(newTestNormalizer(true)) {
+		t.Fatal("normalized tags should not change")
+	}
+}
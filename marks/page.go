@@ -0,0 +1,26 @@
+package marks
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Page is the metadata found in the head of a web page.
+type Page struct {
+	Title        string
+	Description  string
+	CanonicalUrl string
+	Keywords     []string
+}
+
+type Fetcher interface {
+	Fetch(url string) (*Page, error)
+}
+
+var slugBreaks = regexp.MustCompile(`[^a-z0-9]+`)
+
+// Slug lower cases s and joins its words with dashes, dropping everything
+// else, so "Hello, World!" becomes "hello-world".
+func Slug(s string) string {
+	return strings.Trim(slugBreaks.ReplaceAllString(strings.ToLower(s), "-"), "-")
+}
@@ -0,0 +1,16 @@
+package marks
+
+import "testing"
+
+func TestSlug(t *testing.T) {
+	tests := map[string]string{
+		"Hello, World!":                 "hello-world",
+		"  ABC News & Current Affairs ": "abc-news-current-affairs",
+		"---":                           "",
+	}
+	for s, expected := range tests {
+		if actual := Slug(s); actual != expected {
+			t.Fatalf("%v: expected %v, received %v", s, expected, actual)
+		}
+	}
+}
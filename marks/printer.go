@@ -4,10 +4,22 @@ type Printer interface {
 	Msg(string, ...interface{})
 	Error(string, ...interface{})
 	Tabulate([]*Mark) ([]string, error)
+	TabulateLinkStatuses([]*LinkStatus) ([]string, error)
+	TabulateSearches([]*Search) ([]string, error)
+	TabulateSessions([]*Session) ([]string, error)
+	TabulateBrowsers([]*Browser) ([]string, error)
+	TabulateTagCounts([]*TagCount) ([]string, error)
+	TabulateTagTree([]*TagNode) ([]string, error)
 	FullMark(*Mark) (string, error)
 	FullMarkWithFields(*Mark) (string, error)
 	Id(string) (string, error)
 	Url(string) (string, error)
 	Tags([]string) (string, error)
 	Browser(string) (string, error)
+	Record(string, ...*Mark) error
+	RecordTagCounts(string, []*TagCount) error
+	RecordTagTree(string, []*TagNode) error
+	RecordSearches(string, []*Search) error
+	RecordSessions(string, []*Session) error
+	RecordBrowsers(string, []*Browser) error
 }
@@ -2,5 +2,7 @@ package marks
 
 type Prompter interface {
 	Select(string, []string) (int, error)
+	MultiSelect(string, []string) ([]int, error)
+	Input(string) (string, error)
 	Confirm(string) bool
 }
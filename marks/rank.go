@@ -0,0 +1,69 @@
+package marks
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// recencyWeights gives the weight of a visit by how long ago it was, in the
+// manner of Firefox's frecency buckets. Visits older than the last bucket
+// get oldVisitWeight.
+var recencyWeights = []struct {
+	within time.Duration
+	weight float64
+}{
+	{4 * 24 * time.Hour, 100},
+	{14 * 24 * time.Hour, 70},
+	{31 * 24 * time.Hour, 50},
+	{90 * 24 * time.Hour, 30},
+}
+
+const oldVisitWeight = 10
+
+// Frecency scores the mark by how often and how recently it has been
+// opened or copied. Marks that have never been used score zero.
+func (m *Mark) Frecency(now time.Time) float64 {
+	if m.Visits == 0 || m.LastOpenedAt().IsZero() {
+		return 0
+	}
+	age := now.Sub(m.LastOpenedAt())
+	for _, bucket := range recencyWeights {
+		if age <= bucket.within {
+			return float64(m.Visits) * bucket.weight
+		}
+	}
+	return float64(m.Visits) * oldVisitWeight
+}
+
+// Rank orders marks in place, most likely choice first. "frecency" orders
+// by Frecency, "recent" by when each mark was last opened and "alpha" by
+// id. An empty ranking leaves the order unchanged. Ties keep their
+// existing order.
+func Rank(mks []*Mark, by string, now time.Time) error {
+	var less func(a, b *Mark) bool
+	switch by {
+	case "":
+		return nil
+	case "frecency":
+		less = func(a, b *Mark) bool {
+			return a.Frecency(now) > b.Frecency(now)
+		}
+	case "recent":
+		less = func(a, b *Mark) bool {
+			return a.LastOpenedAt().After(b.LastOpenedAt())
+		}
+	case "alpha":
+		less = func(a, b *Mark) bool {
+			return strings.ToLower(a.Id) < strings.ToLower(b.Id)
+		}
+	default:
+		return errors.New(fmt.Sprintf("cannot rank by \"%v\"", by))
+	}
+	sort.SliceStable(mks, func(i, j int) bool {
+		return less(mks[i], mks[j])
+	})
+	return nil
+}
@@ -0,0 +1,57 @@
+package marks
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+var testNow = time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+func TestFrecency(t *testing.T) {
+	tests := []struct {
+		mark     *Mark
+		expected float64
+	}{
+		{&Mark{}, 0},
+		{&Mark{Visits: 3, LastOpened: Timestamp(testNow.AddDate(0, 0, -1))}, 300},
+		{&Mark{Visits: 3, LastOpened: Timestamp(testNow.AddDate(0, 0, -10))}, 210},
+		{&Mark{Visits: 3, LastOpened: Timestamp(testNow.AddDate(0, 0, -20))}, 150},
+		{&Mark{Visits: 3, LastOpened: Timestamp(testNow.AddDate(0, 0, -60))}, 90},
+		{&Mark{Visits: 3, LastOpened: Timestamp(testNow.AddDate(-1, 0, 0))}, 30},
+	}
+	for _, test := range tests {
+		if actual := test.mark.Frecency(testNow); actual != test.expected {
+			t.Fatalf("expected %v, received %v", test.expected, actual)
+		}
+	}
+}
+
+func TestRank(t *testing.T) {
+	daily := &Mark{Id: "daily", Visits: 20, LastOpened: Timestamp(testNow.AddDate(0, 0, -1))}
+	once := &Mark{Id: "once", Visits: 1, LastOpened: Timestamp(testNow)}
+	never := &Mark{Id: "Alpha"}
+	tests := []struct {
+		by       string
+		expected []*Mark
+	}{
+		{"frecency", []*Mark{daily, once, never}},
+		{"recent", []*Mark{once, daily, never}},
+		{"alpha", []*Mark{never, daily, once}},
+	}
+	for _, test := range tests {
+		mks := []*Mark{never, once, daily}
+		if err := Rank(mks, test.by, testNow); err != nil {
+			t.Fatal(err.Error())
+		}
+		if !reflect.DeepEqual(mks, test.expected) {
+			t.Fatalf("%v: expected %v, received %v", test.by, test.expected, mks)
+		}
+	}
+}
+
+func TestRankUnsupported(t *testing.T) {
+	if err := Rank([]*Mark{}, "unsupported", testNow); err == nil {
+		t.Fatal("expected error")
+	}
+}
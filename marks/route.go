@@ -0,0 +1,90 @@
+package marks
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// Route sends marks matching its tag, host glob or url regular expression to
+// a browser, optionally with a different profile. A route with several
+// conditions matches only when all of them do.
+type Route struct {
+	Tag     string `json:"tag,omitempty"`
+	Host    string `json:"host,omitempty"`
+	Url     string `json:"url,omitempty"`
+	Browser string `json:"browser"`
+	Profile string `json:"profile,omitempty"`
+}
+
+// Routing is the browser and profile chosen for a mark, and why.
+type Routing struct {
+	Browser string
+	Profile string
+	Reason  string
+}
+
+// Matches reports whether the mark satisfies every condition of the route.
+// Patterns are checked when the config is loaded, so invalid ones never match.
+func (r *Route) Matches(m *Mark, separator string) bool {
+	if r.Tag != "" && !m.HasTag(r.Tag, separator) {
+		return false
+	}
+	if r.Host != "" {
+		u, err := url.Parse(m.Url)
+		if err != nil {
+			return false
+		}
+		matched, err := path.Match(strings.ToLower(r.Host), strings.ToLower(u.Hostname()))
+		if err != nil || !matched {
+			return false
+		}
+	}
+	if r.Url != "" {
+		re, err := regexp.Compile(r.Url)
+		if err != nil || !re.MatchString(m.Url) {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *Route) String() string {
+	conditions := []string{}
+	if r.Tag != "" {
+		conditions = append(conditions, fmt.Sprintf("tag:%v", r.Tag))
+	}
+	if r.Host != "" {
+		conditions = append(conditions, fmt.Sprintf("host:%v", r.Host))
+	}
+	if r.Url != "" {
+		conditions = append(conditions, fmt.Sprintf("url:%v", r.Url))
+	}
+	return strings.Join(conditions, " ")
+}
+
+// RouteMark chooses the browser for a mark: the browser set on the mark, then
+// the first matching route, then the configured browser.
+func RouteMark(m *Mark, c *Config) *Routing {
+	if m.Browser != "" {
+		return &Routing{
+			Browser: m.Browser,
+			Reason:  fmt.Sprintf("bookmark \"%v\" sets its own browser", m.Id),
+		}
+	}
+	for i, r := range c.Routes {
+		if r.Matches(m, c.TagSeparator) {
+			return &Routing{
+				Browser: r.Browser,
+				Profile: r.Profile,
+				Reason:  fmt.Sprintf("route %v (%v) matched", i+1, r),
+			}
+		}
+	}
+	return &Routing{
+		Browser: c.Browser,
+		Reason:  "no route matched, so the configured browser is used",
+	}
+}
@@ -0,0 +1,62 @@
+package marks
+
+import "testing"
+
+func TestRouteMatches(t *testing.T) {
+	m := &Mark{Id: "Jira", Url: "https://corp.Atlassian.net/browse/ABC-1", Tags: []string{"work/tickets"}}
+	tests := []struct {
+		route    *Route
+		expected bool
+	}{
+		{&Route{Tag: "work"}, true},
+		{&Route{Tag: "home"}, false},
+		{&Route{Host: "*.atlassian.net"}, true},
+		{&Route{Host: "*.github.com"}, false},
+		{&Route{Url: "/browse/[A-Z]+-[0-9]+$"}, true},
+		{&Route{Url: "^http://"}, false},
+		{&Route{Tag: "work", Host: "*.github.com"}, false},
+	}
+	for _, test := range tests {
+		if actual := test.route.Matches(m, "/"); actual != test.expected {
+			t.Fatalf("expected %v for %v, received %v", test.expected, test.route, actual)
+		}
+	}
+}
+
+func TestRouteMark(t *testing.T) {
+	c := &Config{AppConfig: &AppConfig{}, UserConfig: &UserConfig{
+		Browser:      "firefox",
+		TagSeparator: "/",
+		Routes: []*Route{
+			&Route{Host: "*.atlassian.net", Browser: "chrome"},
+			&Route{Tag: "work", Browser: "chrome", Profile: "Work"},
+		},
+	}}
+	tests := []struct {
+		mark     *Mark
+		expected *Routing
+	}{
+		{
+			&Mark{Id: "Docs", Url: "https://docs.corp.com", Tags: []string{"work"}},
+			&Routing{Browser: "chrome", Profile: "Work", Reason: "route 2 (tag:work) matched"},
+		},
+		{
+			&Mark{Id: "Jira", Url: "https://corp.atlassian.net", Tags: []string{"work"}},
+			&Routing{Browser: "chrome", Reason: "route 1 (host:*.atlassian.net) matched"},
+		},
+		{
+			&Mark{Id: "News", Url: "https://www.abc.net.au/news/", Browser: "lynx"},
+			&Routing{Browser: "lynx", Reason: "bookmark \"News\" sets its own browser"},
+		},
+		{
+			&Mark{Id: "Google", Url: "https://www.google.com"},
+			&Routing{Browser: "firefox", Reason: "no route matched, so the configured browser is used"},
+		},
+	}
+	for _, test := range tests {
+		actual := RouteMark(test.mark, c)
+		if *actual != *test.expected {
+			t.Fatalf("expected %v, received %v", test.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,21 @@
+package marks
+
+// Search is a query saved under a name so that it can be run again with
+// "marks search run name" or referenced in other queries as @name.
+type Search struct {
+	Name  string `json:"name"`
+	Query string `json:"query"`
+}
+
+type SearchService interface {
+	Search(name string) (*Search, error)
+	Searches() ([]*Search, error)
+	Save(s *Search) error
+	Delete(name string) error
+}
+
+type SearchDoesNotExistError struct{}
+
+func (e SearchDoesNotExistError) Error() string {
+	return "saved search does not exist"
+}
@@ -0,0 +1,21 @@
+package marks
+
+// Session is an ordered list of marks, by id, that are opened together with
+// "marks session open name".
+type Session struct {
+	Name  string   `json:"name"`
+	Marks []string `json:"marks"`
+}
+
+type SessionService interface {
+	Session(name string) (*Session, error)
+	Sessions() ([]*Session, error)
+	Save(s *Session) error
+	Delete(name string) error
+}
+
+type SessionDoesNotExistError struct{}
+
+func (e SessionDoesNotExistError) Error() string {
+	return "session does not exist"
+}
@@ -0,0 +1,157 @@
+package marks
+
+import (
+	"sort"
+	"strings"
+)
+
+// TagCount is the number of marks carrying a tag.
+type TagCount struct {
+	Tag   string `json:"tag"`
+	Count int    `json:"count"`
+}
+
+// CountTags counts the marks carrying each tag, most used first. Tags that
+// differ only by case are counted together under the first spelling seen.
+func CountTags(mks []*Mark) []*TagCount {
+	byTag := map[string]*TagCount{}
+	counts := []*TagCount{}
+	for _, m := range mks {
+		seen := map[string]bool{}
+		for _, tag := range m.Tags {
+			key := strings.ToLower(tag)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			count, ok := byTag[key]
+			if !ok {
+				count = &TagCount{Tag: tag}
+				byTag[key] = count
+				counts = append(counts, count)
+			}
+			count.Count++
+		}
+	}
+	sort.SliceStable(counts, func(i, j int) bool {
+		if counts[i].Count != counts[j].Count {
+			return counts[i].Count > counts[j].Count
+		}
+		return strings.ToLower(counts[i].Tag) < strings.ToLower(counts[j].Tag)
+	})
+	return counts
+}
+
+// TagMatches reports whether tag is parent or, when sep is not empty, one of
+// its descendants, so that "work" matches "work/projectx/ci". Case is ignored.
+func TagMatches(parent, tag, sep string) bool {
+	if strings.EqualFold(parent, tag) {
+		return true
+	}
+	if sep == "" || len(tag) <= len(parent)+len(sep) {
+		return false
+	}
+	return strings.EqualFold(tag[:len(parent)+len(sep)], parent+sep)
+}
+
+// HasTag reports whether any of the mark's tags is tag or one of its
+// descendants.
+func (m *Mark) HasTag(tag, sep string) bool {
+	for _, t := range m.Tags {
+		if TagMatches(tag, t, sep) {
+			return true
+		}
+	}
+	return false
+}
+
+// TagNode is one level of the tag hierarchy. Count is the number of marks
+// carrying the tag or any of its descendants.
+type TagNode struct {
+	Name     string     `json:"name"`
+	Path     string     `json:"path"`
+	Count    int        `json:"count"`
+	Children []*TagNode `json:"children,omitempty" yaml:"children,omitempty"`
+}
+
+// TagTree arranges the marks' tags into a hierarchy split on sep, sorted
+// alphabetically at each level. Tags that differ only by case share a node
+// under the first spelling seen.
+func TagTree(mks []*Mark, sep string) []*TagNode {
+	root := &TagNode{}
+	byPath := map[string]*TagNode{}
+	for _, m := range mks {
+		counted := map[string]bool{}
+		for _, tag := range m.Tags {
+			parent := root
+			segments := []string{tag}
+			if sep != "" {
+				segments = strings.Split(tag, sep)
+			}
+			for i := range segments {
+				path := strings.Join(segments[:i+1], sep)
+				key := strings.ToLower(path)
+				node, ok := byPath[key]
+				if !ok {
+					node = &TagNode{Name: segments[i], Path: path}
+					byPath[key] = node
+					parent.Children = append(parent.Children, node)
+				}
+				if !counted[key] {
+					counted[key] = true
+					node.Count++
+				}
+				parent = node
+			}
+		}
+	}
+	sortTagNodes(root.Children)
+	return root.Children
+}
+
+func sortTagNodes(nodes []*TagNode) {
+	sort.SliceStable(nodes, func(i, j int) bool {
+		return strings.ToLower(nodes[i].Name) < strings.ToLower(nodes[j].Name)
+	})
+	for _, node := range nodes {
+		sortTagNodes(node.Children)
+	}
+}
+
+// Retag replaces any of the mark's tags matching from with to, in the
+// position of the first one replaced, or removes them if to is empty.
+// Descendants of a tag in from, split on sep, are moved beneath to, or
+// removed along with it. It reports whether the tags changed.
+func (m *Mark) Retag(from []string, to string, sep string) bool {
+	replace := func(tag string) (string, bool) {
+		for _, f := range from {
+			if TagMatches(f, tag, sep) {
+				if to == "" {
+					return "", true
+				}
+				return to + tag[len(f):], true
+			}
+		}
+		return tag, false
+	}
+	retagged := []string{}
+	seen := map[string]bool{}
+	add := func(tag string) {
+		if !seen[strings.ToLower(tag)] {
+			seen[strings.ToLower(tag)] = true
+			retagged = append(retagged, tag)
+		}
+	}
+	for _, tag := range m.Tags {
+		if replaced, ok := replace(tag); !ok {
+			add(tag)
+		} else if replaced != "" {
+			add(replaced)
+		}
+	}
+	if sameTags(retagged, m.Tags) {
+		return false
+	}
+	m.Tags = retagged
+	return true
+}
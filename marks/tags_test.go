@@ -0,0 +1,98 @@
+package marks
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCountTags(t *testing.T) {
+	mks := []*Mark{
+		&Mark{Tags: []string{"news", "uk"}},
+		&Mark{Tags: []string{"News", "au"}},
+		&Mark{Tags: []string{"uk"}},
+		&Mark{Tags: []string{"au", "AU"}},
+		&Mark{Tags: []string{"news"}},
+	}
+	expected := []*TagCount{
+		&TagCount{"news", 3},
+		&TagCount{"au", 2},
+		&TagCount{"uk", 2},
+	}
+	actual := CountTags(mks)
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %v, received %v", expected, actual)
+	}
+}
+
+func TestRetag(t *testing.T) {
+	tests := []struct {
+		tags     []string
+		from     []string
+		to       string
+		sep      string
+		expected []string
+		changed  bool
+	}{
+		{[]string{"news", "uk"}, []string{"uk"}, "britain", "/", []string{"news", "britain"}, true},
+		{[]string{"News", "uk"}, []string{"news"}, "news", "/", []string{"news", "uk"}, true},
+		{[]string{"a", "x", "b"}, []string{"a", "b"}, "c", "/", []string{"c", "x"}, true},
+		{[]string{"c", "a"}, []string{"a"}, "c", "/", []string{"c"}, true},
+		{[]string{"news", "uk"}, []string{"uk"}, "", "/", []string{"news"}, true},
+		{[]string{"news"}, []string{"uk"}, "britain", "/", []string{"news"}, false},
+		{[]string{"work/a", "work", "home"}, []string{"work"}, "job", "/", []string{"job/a", "job", "home"}, true},
+		{[]string{"Work/A/ci", "workshop"}, []string{"work"}, "job", "/", []string{"job/A/ci", "workshop"}, true},
+		{[]string{"work/a", "home"}, []string{"work"}, "", "/", []string{"home"}, true},
+		{[]string{"work/a", "home"}, []string{"work"}, "job", "", []string{"work/a", "home"}, false},
+	}
+	for _, test := range tests {
+		m := &Mark{Tags: test.tags}
+		changed := m.Retag(test.from, test.to, test.sep)
+		if changed != test.changed || !reflect.DeepEqual(m.Tags, test.expected) {
+			t.Fatalf("expected %v %v, received %v %v", test.expected, test.changed, m.Tags, changed)
+		}
+	}
+}
+
+func TestTagMatches(t *testing.T) {
+	tests := []struct {
+		parent   string
+		tag      string
+		sep      string
+		expected bool
+	}{
+		{"work", "work", "/", true},
+		{"Work", "work/projectx", "/", true},
+		{"work/projectx", "WORK/ProjectX/ci", "/", true},
+		{"work", "workshop", "/", false},
+		{"work", "work/", "/", false},
+		{"work/projectx", "work", "/", false},
+		{"work", "work/projectx", "", false},
+		{"work", "work::projectx", "::", true},
+	}
+	for _, test := range tests {
+		if actual := TagMatches(test.parent, test.tag, test.sep); actual != test.expected {
+			t.Fatalf("%v %v: expected %v, received %v", test.parent, test.tag, test.expected, actual)
+		}
+	}
+}
+
+func TestTagTree(t *testing.T) {
+	mks := []*Mark{
+		&Mark{Tags: []string{"work/projectx/ci", "work/projectx"}},
+		&Mark{Tags: []string{"Work/ProjectX", "news"}},
+		&Mark{Tags: []string{"work/admin"}},
+	}
+	expected := []*TagNode{
+		&TagNode{"news", "news", 1, nil},
+		&TagNode{"work", "work", 3, []*TagNode{
+			&TagNode{"admin", "work/admin", 1, nil},
+			&TagNode{"projectx", "work/projectx", 2, []*TagNode{
+				&TagNode{"ci", "work/projectx/ci", 1, nil},
+			}},
+		}},
+	}
+	actual := TagTree(mks, "/")
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %v, received %v", expected, actual)
+	}
+}
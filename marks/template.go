@@ -0,0 +1,89 @@
+package marks
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"text/template"
+)
+
+// urlTemplateFuncs are available to url templates along with text/template's
+// own urlquery.
+var urlTemplateFuncs = template.FuncMap{
+	"pathescape":  url.PathEscape,
+	"queryescape": url.QueryEscape,
+}
+
+// IsUrlTemplate reports whether the url has placeholders, as in
+// https://jira.example.com/browse/{{.Args 0}}, to be filled in when it is
+// opened.
+func IsUrlTemplate(raw string) bool {
+	return strings.Contains(raw, "{{")
+}
+
+// urlParams is the data a url template is executed with. Parameters that
+// were not given are prompted for.
+type urlParams struct {
+	args     []string
+	prompted map[int]string
+	prompt   func(label string) (string, error)
+}
+
+// Args returns the i'th (from 0) argument, prompting for it, and only it,
+// if it was not given.
+func (p *urlParams) Args(i int) (string, error) {
+	if i < 0 {
+		return "", errors.New(fmt.Sprintf("argument %v is out of range", i))
+	}
+	if i < len(p.args) {
+		return p.args[i], nil
+	}
+	if arg, ok := p.prompted[i]; ok {
+		return arg, nil
+	}
+	arg, err := p.prompt(fmt.Sprintf("Argument %v", i))
+	if err != nil {
+		return "", err
+	}
+	p.prompted[i] = arg
+	return arg, nil
+}
+
+// Query returns the arguments joined with spaces.
+func (p *urlParams) Query() (string, error) {
+	if len(p.args) == 0 {
+		query, err := p.prompt("Query")
+		if err != nil {
+			return "", err
+		}
+		p.args = strings.Fields(query)
+	}
+	return strings.Join(p.args, " "), nil
+}
+
+// ExpandUrl fills in a url template with args, calling prompt for any that
+// are missing. Urls without placeholders are returned unchanged.
+func ExpandUrl(raw string, args []string, prompt func(label string) (string, error)) (string, error) {
+	if !IsUrlTemplate(raw) {
+		return raw, nil
+	}
+	tmpl, err := template.New("url").Funcs(urlTemplateFuncs).Parse(raw)
+	if err != nil {
+		return "", err
+	}
+	builder := strings.Builder{}
+	params := &urlParams{append([]string{}, args...), map[int]string{}, prompt}
+	if err := tmpl.Execute(&builder, params); err != nil {
+		return "", err
+	}
+	return builder.String(), nil
+}
+
+// ValidateUrlTemplate checks that the url template can be filled in.
+func ValidateUrlTemplate(raw string) error {
+	_, err := ExpandUrl(raw, nil, func(string) (string, error) {
+		return "x", nil
+	})
+	return err
+}
@@ -0,0 +1,97 @@
+package marks
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestExpandUrl(t *testing.T) {
+	tests := []struct {
+		raw      string
+		args     []string
+		expected string
+	}{
+		{"https://jira.example.com/browse/{{.Args 0}}", []string{"PROJ-123"}, "https://jira.example.com/browse/PROJ-123"},
+		{"https://github.com/search?q={{.Query | urlquery}}", []string{"go", "templates"}, "https://github.com/search?q=go+templates"},
+		{"https://en.wikipedia.org/wiki/{{.Args 0 | pathescape}}", []string{"Go (language)"}, "https://en.wikipedia.org/wiki/Go%20%28language%29"},
+		{"https://example.com/{{.Args 0}}/{{.Args 1 | queryescape}}", []string{"a", "b&c"}, "https://example.com/a/b%26c"},
+		{"https://www.abc.net.au/news/", []string{"ignored"}, "https://www.abc.net.au/news/"},
+	}
+	for _, test := range tests {
+		actual, err := ExpandUrl(test.raw, test.args, nil)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if actual != test.expected {
+			t.Fatalf("expected %v, received %v", test.expected, actual)
+		}
+	}
+}
+
+func TestExpandUrlPromptsForMissingArgs(t *testing.T) {
+	labels := []string{}
+	prompt := func(label string) (string, error) {
+		labels = append(labels, label)
+		return "answer", nil
+	}
+	actual, err := ExpandUrl("https://example.com/{{.Args 0}}/{{.Args 2}}", []string{"given"}, prompt)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if actual != "https://example.com/given/answer" {
+		t.Fatalf("unexpected url %v", actual)
+	}
+	if !reflect.DeepEqual(labels, []string{"Argument 2"}) {
+		t.Fatalf("unexpected prompts %v", labels)
+	}
+}
+
+func TestExpandUrlPromptsOncePerArg(t *testing.T) {
+	labels := []string{}
+	prompt := func(label string) (string, error) {
+		labels = append(labels, label)
+		return "answer", nil
+	}
+	actual, err := ExpandUrl("https://example.com/{{.Args 1}}?again={{.Args 1}}", nil, prompt)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if actual != "https://example.com/answer?again=answer" {
+		t.Fatalf("unexpected url %v", actual)
+	}
+	if !reflect.DeepEqual(labels, []string{"Argument 1"}) {
+		t.Fatalf("unexpected prompts %v", labels)
+	}
+}
+
+func TestExpandUrlPromptError(t *testing.T) {
+	prompt := func(label string) (string, error) {
+		return "", errors.New("interrupted")
+	}
+	if _, err := ExpandUrl("https://example.com/?q={{.Query}}", nil, prompt); err == nil {
+		t.Fatal("should return error")
+	}
+}
+
+func TestValidateUrlTemplate(t *testing.T) {
+	valid := []string{
+		"https://jira.example.com/browse/{{.Args 0}}",
+		"https://github.com/search?q={{.Query | urlquery}}",
+	}
+	for _, raw := range valid {
+		if err := ValidateUrlTemplate(raw); err != nil {
+			t.Fatalf("expected %v to be valid: %v", raw, err.Error())
+		}
+	}
+	invalid := []string{
+		"https://example.com/{{.Args 0}",
+		"https://example.com/{{.Search}}",
+		"https://example.com/{{.Query | shout}}",
+	}
+	for _, raw := range invalid {
+		if err := ValidateUrlTemplate(raw); err == nil {
+			t.Fatalf("expected %v to be invalid", raw)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package marks
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// CanonicalUrl reduces a url to a form shared by urls that point at the same
+// page. The scheme, "www." prefix, default ports, trailing slashes and utm_*
+// tracking parameters are dropped, the host is lower cased and the remaining
+// query parameters are sorted. Urls that cannot be parsed are only trimmed
+// and lower cased.
+func CanonicalUrl(raw string) string {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return ""
+	}
+	withScheme := raw
+	if !strings.Contains(raw, "://") {
+		withScheme = "http://" + raw
+	}
+	u, err := url.Parse(withScheme)
+	if err != nil || u.Host == "" {
+		return strings.ToLower(raw)
+	}
+
+	host := strings.ToLower(u.Hostname())
+	host = strings.TrimPrefix(host, "www.")
+	if port := u.Port(); port != "" && port != "80" && port != "443" {
+		host = host + ":" + port
+	}
+
+	path := strings.TrimRight(u.EscapedPath(), "/")
+
+	params := u.Query()
+	for key := range params {
+		if strings.HasPrefix(strings.ToLower(key), "utm_") {
+			params.Del(key)
+		}
+	}
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	pairs := []string{}
+	for _, key := range keys {
+		values := append([]string{}, params[key]...)
+		sort.Strings(values)
+		for _, value := range values {
+			pairs = append(pairs, url.QueryEscape(key)+"="+url.QueryEscape(value))
+		}
+	}
+
+	canonical := host + path
+	if len(pairs) > 0 {
+		canonical += "?" + strings.Join(pairs, "&")
+	}
+	if u.Fragment != "" {
+		canonical += "#" + u.Fragment
+	}
+	return canonical
+}
+
+// Duplicates groups marks whose urls share a canonical form, in the order
+// each group's first mark appears. Marks without a url are never duplicates.
+func Duplicates(mks []*Mark) [][]*Mark {
+	byUrl := map[string][]*Mark{}
+	order := []string{}
+	for _, m := range mks {
+		canonical := CanonicalUrl(m.Url)
+		if canonical == "" {
+			continue
+		}
+		if _, ok := byUrl[canonical]; !ok {
+			order = append(order, canonical)
+		}
+		byUrl[canonical] = append(byUrl[canonical], m)
+	}
+	groups := [][]*Mark{}
+	for _, canonical := range order {
+		if len(byUrl[canonical]) > 1 {
+			groups = append(groups, byUrl[canonical])
+		}
+	}
+	return groups
+}
+
+// Merge returns a copy of keep carrying the tags of every mark in others as
+// well. It keeps the oldest created time, the latest opened time and the
+// sum of the visits across all of them. Differing notes are appended, and
+// the description and browser of the first of the others to have one fill
+// in any keep lacks.
+func Merge(keep *Mark, others []*Mark) *Mark {
+	merged := keep.Copy()
+	seen := map[string]bool{}
+	for _, tag := range merged.Tags {
+		seen[strings.ToLower(tag)] = true
+	}
+	for _, other := range others {
+		for _, tag := range other.Tags {
+			if !seen[strings.ToLower(tag)] {
+				seen[strings.ToLower(tag)] = true
+				merged.Tags = append(merged.Tags, tag)
+			}
+		}
+		if !other.CreatedAt().IsZero() && (merged.CreatedAt().IsZero() || other.CreatedAt().Before(merged.CreatedAt())) {
+			merged.Created = other.Created
+		}
+		if other.LastOpenedAt().After(merged.LastOpenedAt()) {
+			merged.LastOpened = other.LastOpened
+		}
+		merged.Visits += other.Visits
+		merged.Notes = mergeNotes(merged.Notes, other.Notes)
+		if merged.Description == "" {
+			merged.Description = other.Description
+		}
+		if merged.Browser == "" {
+			merged.Browser = other.Browser
+		}
+	}
+	return merged
+}
+
+// mergeNotes appends other to notes, separated by a blank line, unless
+// notes already holds it.
+func mergeNotes(notes, other string) string {
+	other = strings.TrimSpace(other)
+	if other == "" || strings.Contains(notes, other) {
+		return notes
+	}
+	if strings.TrimSpace(notes) == "" {
+		return other
+	}
+	return strings.TrimRight(notes, "\n") + "\n\n" + other
+}
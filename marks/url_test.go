@@ -0,0 +1,81 @@
+package marks
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCanonicalUrl(t *testing.T) {
+	tests := []struct {
+		url      string
+		expected string
+	}{
+		{"https://www.abc.net.au/news/", "abc.net.au/news"},
+		{"http://abc.net.au/news", "abc.net.au/news"},
+		{"HTTPS://WWW.ABC.NET.AU/news", "abc.net.au/news"},
+		{"abc.net.au/news/", "abc.net.au/news"},
+		{"https://abc.net.au:443/", "abc.net.au"},
+		{"https://abc.net.au:8080/", "abc.net.au:8080"},
+		{"https://abc.net.au/News", "abc.net.au/News"},
+		{"https://abc.net.au/?utm_source=x&b=2&a=1&UTM_medium=y", "abc.net.au?a=1&b=2"},
+		{"https://abc.net.au/#top", "abc.net.au#top"},
+		{"", ""},
+	}
+	for _, test := range tests {
+		if actual := CanonicalUrl(test.url); actual != test.expected {
+			t.Fatalf("%v: expected %v, received %v", test.url, test.expected, actual)
+		}
+	}
+}
+
+func TestDuplicates(t *testing.T) {
+	a := &Mark{Id: "a", Url: "https://www.abc.net.au/news/"}
+	b := &Mark{Id: "b", Url: "https://bbc.com"}
+	c := &Mark{Id: "c", Url: "http://abc.net.au/news?utm_source=rss"}
+	d := &Mark{Id: "d"}
+	e := &Mark{Id: "e"}
+	expected := [][]*Mark{{a, c}}
+	actual := Duplicates([]*Mark{a, b, c, d, e})
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %v, received %v", expected, actual)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	older := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	newer := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	keep := &Mark{Id: "keep", Url: "https://abc.net.au", Tags: []string{"news"}, Created: Timestamp(newer), Visits: 2}
+	other := &Mark{Id: "other", Tags: []string{"News", "au"}, Created: Timestamp(older), LastOpened: Timestamp(newer), Visits: 3}
+	expected := &Mark{
+		Id:         "keep",
+		Url:        "https://abc.net.au",
+		Tags:       []string{"news", "au"},
+		Created:    Timestamp(older),
+		LastOpened: Timestamp(newer),
+		Visits:     5,
+	}
+	actual := Merge(keep, []*Mark{other})
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %v, received %v", expected, actual)
+	}
+	if len(keep.Tags) != 1 {
+		t.Fatal("keep should not be modified")
+	}
+}
+
+func TestMergeNotesDescriptionAndBrowser(t *testing.T) {
+	keep := &Mark{Id: "keep", Notes: "Saved for the election\n"}
+	others := []*Mark{
+		&Mark{Id: "a", Notes: "Paywalled after 5 articles", Description: "ABC News", Browser: "firefox"},
+		&Mark{Id: "b", Notes: "Saved for the election", Description: "Other", Browser: "chrome"},
+	}
+	actual := Merge(keep, others)
+	expected := "Saved for the election\n\nPaywalled after 5 articles"
+	if actual.Notes != expected {
+		t.Fatalf("expected %q, received %q", expected, actual.Notes)
+	}
+	if actual.Description != "ABC News" || actual.Browser != "firefox" {
+		t.Fatalf("expected the first description and browser, received %v %v", actual.Description, actual.Browser)
+	}
+}
@@ -0,0 +1,27 @@
+package mocks
+
+import "github.com/tomguerney/marks/marks"
+
+type Checker struct {
+	CheckFn       func([]*marks.Mark) []*marks.LinkStatus
+	CheckFnCalled bool
+}
+
+func NewChecker() *Checker {
+	return &Checker{
+		CheckFn: defaultCheckFn,
+	}
+}
+
+func (c *Checker) Check(mks []*marks.Mark) []*marks.LinkStatus {
+	c.CheckFnCalled = true
+	return c.CheckFn(mks)
+}
+
+var defaultCheckFn = func(mks []*marks.Mark) []*marks.LinkStatus {
+	statuses := []*marks.LinkStatus{}
+	for _, m := range mks {
+		statuses = append(statuses, &marks.LinkStatus{Mark: m, StatusCode: 200})
+	}
+	return statuses
+}
@@ -0,0 +1,21 @@
+package mocks
+
+type Editor struct {
+	EditFn       func(string, string) (string, error)
+	EditFnCalled bool
+}
+
+func NewEditor() *Editor {
+	return &Editor{
+		EditFn: defaultEditFn,
+	}
+}
+
+func (e *Editor) Edit(text, ext string) (string, error) {
+	e.EditFnCalled = true
+	return e.EditFn(text, ext)
+}
+
+var defaultEditFn = func(text, ext string) (string, error) {
+	return text, nil
+}
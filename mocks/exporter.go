@@ -0,0 +1,23 @@
+package mocks
+
+import "github.com/tomguerney/marks/marks"
+
+type Exporter struct {
+	ExportFn       func([]*marks.Mark) ([]byte, error)
+	ExportFnCalled bool
+}
+
+func NewExporter() *Exporter {
+	return &Exporter{
+		ExportFn: defaultExportFn,
+	}
+}
+
+func (e *Exporter) Export(mks []*marks.Mark) ([]byte, error) {
+	e.ExportFnCalled = true
+	return e.ExportFn(mks)
+}
+
+var defaultExportFn = func([]*marks.Mark) ([]byte, error) {
+	return []byte("exported"), nil
+}
@@ -0,0 +1,27 @@
+package mocks
+
+import "github.com/tomguerney/marks/marks"
+
+type Fetcher struct {
+	FetchFn       func(string) (*marks.Page, error)
+	FetchFnCalled bool
+}
+
+func NewFetcher() *Fetcher {
+	return &Fetcher{
+		FetchFn: defaultFetchFn,
+	}
+}
+
+func (f *Fetcher) Fetch(url string) (*marks.Page, error) {
+	f.FetchFnCalled = true
+	return f.FetchFn(url)
+}
+
+var defaultFetchFn = func(string) (*marks.Page, error) {
+	return &marks.Page{
+		Title:       "Example Domain",
+		Description: "An example page",
+		Keywords:    []string{"example", "news"},
+	}, nil
+}
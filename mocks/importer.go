@@ -0,0 +1,25 @@
+package mocks
+
+import "github.com/tomguerney/marks/marks"
+
+type Importer struct {
+	ImportFn       func([]byte) ([]*marks.Mark, error)
+	ImportFnCalled bool
+}
+
+func NewImporter() *Importer {
+	return &Importer{
+		ImportFn: defaultImportFn,
+	}
+}
+
+func (i *Importer) Import(b []byte) ([]*marks.Mark, error) {
+	i.ImportFnCalled = true
+	return i.ImportFn(b)
+}
+
+var defaultImportFn = func([]byte) ([]*marks.Mark, error) {
+	return []*marks.Mark{
+		&marks.Mark{Id: "Github", Url: "https://github.com/"},
+	}, nil
+}
@@ -3,31 +3,43 @@ package mocks
 import "github.com/tomguerney/marks/marks"
 
 type MarkService struct {
-	MarkFn           func(id string) (*marks.Mark, error)
-	MarksFn          func() ([]*marks.Mark, error)
-	CreateFn         func(m *marks.Mark) error
-	UpdateFn         func(id string, m *marks.Mark) error
-	DeleteFn         func(id string) error
-	ContainsFn       func(id string) (bool, error)
-	FilterFn         func(id, url string, tags []string) ([]*marks.Mark, error)
-	MarkFnCalled     bool
-	MarksFnCalled    bool
-	CreateFnCalled   bool
-	UpdateFnCalled   bool
-	DeleteFnCalled   bool
-	ContainsFnCalled bool
-	FilterFnCalled   bool
+	MarkFn            func(id string) (*marks.Mark, error)
+	MarksFn           func() ([]*marks.Mark, error)
+	CreateFn          func(m *marks.Mark) error
+	CreateAllFn       func(mks []*marks.Mark) error
+	UpdateFn          func(id string, m *marks.Mark) error
+	UpdateAllFn       func(updates map[string]*marks.Mark) error
+	DeleteFn          func(id string) error
+	ApplyFn           func(c *marks.Changeset) error
+	ContainsFn        func(id string) (bool, error)
+	FilterFn          func(id, url string, tags []string) ([]*marks.Mark, error)
+	SearchFn          func(expr string) ([]*marks.Mark, error)
+	MarkFnCalled      bool
+	MarksFnCalled     bool
+	CreateFnCalled    bool
+	CreateAllFnCalled bool
+	UpdateFnCalled    bool
+	UpdateAllFnCalled bool
+	DeleteFnCalled    bool
+	ApplyFnCalled     bool
+	ContainsFnCalled  bool
+	FilterFnCalled    bool
+	SearchFnCalled    bool
 }
 
 func NewMarkService() *MarkService {
 	return &MarkService{
-		MarkFn:     defaultMarkFn,
-		MarksFn:    defaultMarksFn,
-		CreateFn:   defaultCreateFn,
-		UpdateFn:   defaultUpdateFn,
-		DeleteFn:   defaultDeleteFn,
-		ContainsFn: defaultContainsFn,
-		FilterFn:   defaultFilterFn,
+		MarkFn:      defaultMarkFn,
+		MarksFn:     defaultMarksFn,
+		CreateFn:    defaultCreateFn,
+		CreateAllFn: defaultCreateAllFn,
+		UpdateFn:    defaultUpdateFn,
+		UpdateAllFn: defaultUpdateAllFn,
+		DeleteFn:    defaultDeleteFn,
+		ApplyFn:     defaultApplyFn,
+		ContainsFn:  defaultContainsFn,
+		FilterFn:    defaultFilterFn,
+		SearchFn:    defaultSearchFn,
 	}
 }
 
@@ -43,10 +55,18 @@ var defaultCreateFn = func(m *marks.Mark) error {
 	return nil
 }
 
+var defaultCreateAllFn = func(mks []*marks.Mark) error {
+	return nil
+}
+
 var defaultUpdateFn = func(id string, m *marks.Mark) error {
 	return nil
 }
 
+var defaultUpdateAllFn = func(updates map[string]*marks.Mark) error {
+	return nil
+}
+
 var defaultDeleteFn = func(id string) error {
 	return nil
 }
@@ -59,6 +79,10 @@ var defaultFilterFn = func(id, url string, tags []string) ([]*marks.Mark, error)
 	return []*marks.Mark{DefaultMarks[0], DefaultMarks[1]}, nil
 }
 
+var defaultSearchFn = func(expr string) ([]*marks.Mark, error) {
+	return []*marks.Mark{DefaultMarks[0], DefaultMarks[1]}, nil
+}
+
 func (s *MarkService) Mark(id string) (*marks.Mark, error) {
 	s.MarkFnCalled = true
 	return s.MarkFn(id)
@@ -74,11 +98,21 @@ func (s *MarkService) Create(m *marks.Mark) error {
 	return s.CreateFn(m)
 }
 
+func (s *MarkService) CreateAll(mks []*marks.Mark) error {
+	s.CreateAllFnCalled = true
+	return s.CreateAllFn(mks)
+}
+
 func (s *MarkService) Update(id string, m *marks.Mark) error {
 	s.UpdateFnCalled = true
 	return s.UpdateFn(id, m)
 }
 
+func (s *MarkService) UpdateAll(updates map[string]*marks.Mark) error {
+	s.UpdateAllFnCalled = true
+	return s.UpdateAllFn(updates)
+}
+
 func (s *MarkService) Delete(id string) error {
 	s.DeleteFnCalled = true
 	return s.DeleteFn(id)
@@ -93,3 +127,17 @@ func (s *MarkService) Filter(id, url string, tags []string) ([]*marks.Mark, erro
 	s.FilterFnCalled = true
 	return s.FilterFn(id, url, tags)
 }
+
+func (s *MarkService) Search(expr string) ([]*marks.Mark, error) {
+	s.SearchFnCalled = true
+	return s.SearchFn(expr)
+}
+
+func (s *MarkService) Apply(c *marks.Changeset) error {
+	s.ApplyFnCalled = true
+	return s.ApplyFn(c)
+}
+
+var defaultApplyFn = func(c *marks.Changeset) error {
+	return nil
+}
@@ -1,7 +1,7 @@
 package mocks
 
 type Opener struct {
-	OpenFn       func(string, string) error
+	OpenFn       func(string, string, string) error
 	OpenFnCalled bool
 }
 
@@ -11,11 +11,11 @@ func NewOpener() *Opener {
 	}
 }
 
-func (c *Opener) Open(s1, s2 string) error {
+func (c *Opener) Open(s1, s2, s3 string) error {
 	c.OpenFnCalled = true
-	return c.OpenFn(s1, s2)
+	return c.OpenFn(s1, s2, s3)
 }
 
-var defaultOpenFn = func(string, string) error {
+var defaultOpenFn = func(string, string, string) error {
 	return nil
 }
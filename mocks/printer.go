@@ -7,37 +7,73 @@ import (
 )
 
 type Printer struct {
-	MsgFn                      func(string, ...interface{})
-	ErrorFn                    func(string, ...interface{})
-	TabulateFn                 func([]*marks.Mark) ([]string, error)
-	FullMarkFn                 func(*marks.Mark) (string, error)
-	FullMarkWithFieldsFn       func(*marks.Mark) (string, error)
-	IdFn                       func(string) (string, error)
-	UrlFn                      func(string) (string, error)
-	TagsFn                     func([]string) (string, error)
-	BrowserFn                  func(string) (string, error)
-	MsgFnCalled                bool
-	ErrorFnCalled              bool
-	TabulateFnCalled           bool
-	FullMarkFnCalled           bool
-	FullMarkWithFieldsFnCalled bool
-	IdFnCalled                 bool
-	UrlFnCalled                bool
-	TagsFnCalled               bool
-	BrowserFnCalled            bool
+	MsgFn                        func(string, ...interface{})
+	ErrorFn                      func(string, ...interface{})
+	TabulateFn                   func([]*marks.Mark) ([]string, error)
+	TabulateLinkStatusesFn       func([]*marks.LinkStatus) ([]string, error)
+	TabulateSearchesFn           func([]*marks.Search) ([]string, error)
+	TabulateSessionsFn           func([]*marks.Session) ([]string, error)
+	TabulateBrowsersFn           func([]*marks.Browser) ([]string, error)
+	TabulateTagCountsFn          func([]*marks.TagCount) ([]string, error)
+	TabulateTagTreeFn            func([]*marks.TagNode) ([]string, error)
+	FullMarkFn                   func(*marks.Mark) (string, error)
+	FullMarkWithFieldsFn         func(*marks.Mark) (string, error)
+	IdFn                         func(string) (string, error)
+	UrlFn                        func(string) (string, error)
+	TagsFn                       func([]string) (string, error)
+	BrowserFn                    func(string) (string, error)
+	RecordFn                     func(string, ...*marks.Mark) error
+	RecordTagCountsFn            func(string, []*marks.TagCount) error
+	RecordTagTreeFn              func(string, []*marks.TagNode) error
+	RecordSearchesFn             func(string, []*marks.Search) error
+	RecordSessionsFn             func(string, []*marks.Session) error
+	RecordBrowsersFn             func(string, []*marks.Browser) error
+	MsgFnCalled                  bool
+	ErrorFnCalled                bool
+	TabulateFnCalled             bool
+	TabulateLinkStatusesFnCalled bool
+	TabulateSearchesFnCalled     bool
+	TabulateSessionsFnCalled     bool
+	TabulateBrowsersFnCalled     bool
+	TabulateTagCountsFnCalled    bool
+	TabulateTagTreeFnCalled      bool
+	FullMarkFnCalled             bool
+	FullMarkWithFieldsFnCalled   bool
+	IdFnCalled                   bool
+	UrlFnCalled                  bool
+	TagsFnCalled                 bool
+	BrowserFnCalled              bool
+	RecordFnCalled               bool
+	RecordTagCountsFnCalled      bool
+	RecordTagTreeFnCalled        bool
+	RecordSearchesFnCalled       bool
+	RecordSessionsFnCalled       bool
+	RecordBrowsersFnCalled       bool
 }
 
 func NewPrinter() *Printer {
 	return &Printer{
-		MsgFn:                defaultMsgFn,
-		ErrorFn:              defaultErrorFn,
-		TabulateFn:           defaultTabulateFn,
-		FullMarkFn:           defaultFullMarkFn,
-		FullMarkWithFieldsFn: defaultFullMarkWithFieldsFn,
-		IdFn:                 defaultIdFn,
-		UrlFn:                defaultUrlFn,
-		TagsFn:               defaultTagsFn,
-		BrowserFn:            defaultBrowserFn,
+		MsgFn:                  defaultMsgFn,
+		ErrorFn:                defaultErrorFn,
+		TabulateFn:             defaultTabulateFn,
+		TabulateLinkStatusesFn: defaultTabulateLinkStatusesFn,
+		TabulateSearchesFn:     defaultTabulateSearchesFn,
+		TabulateSessionsFn:     defaultTabulateSessionsFn,
+		TabulateBrowsersFn:     defaultTabulateBrowsersFn,
+		TabulateTagCountsFn:    defaultTabulateTagCountsFn,
+		TabulateTagTreeFn:      defaultTabulateTagTreeFn,
+		FullMarkFn:             defaultFullMarkFn,
+		FullMarkWithFieldsFn:   defaultFullMarkWithFieldsFn,
+		IdFn:                   defaultIdFn,
+		UrlFn:                  defaultUrlFn,
+		TagsFn:                 defaultTagsFn,
+		BrowserFn:              defaultBrowserFn,
+		RecordFn:               defaultRecordFn,
+		RecordTagCountsFn:      defaultRecordTagCountsFn,
+		RecordTagTreeFn:        defaultRecordTagTreeFn,
+		RecordSearchesFn:       defaultRecordSearchesFn,
+		RecordSessionsFn:       defaultRecordSessionsFn,
+		RecordBrowsersFn:       defaultRecordBrowsersFn,
 	}
 }
 
@@ -56,6 +92,36 @@ func (p *Printer) Tabulate(mks []*marks.Mark) ([]string, error) {
 	return p.TabulateFn(mks)
 }
 
+func (p *Printer) TabulateLinkStatuses(statuses []*marks.LinkStatus) ([]string, error) {
+	p.TabulateLinkStatusesFnCalled = true
+	return p.TabulateLinkStatusesFn(statuses)
+}
+
+func (p *Printer) TabulateSearches(searches []*marks.Search) ([]string, error) {
+	p.TabulateSearchesFnCalled = true
+	return p.TabulateSearchesFn(searches)
+}
+
+func (p *Printer) TabulateSessions(sessions []*marks.Session) ([]string, error) {
+	p.TabulateSessionsFnCalled = true
+	return p.TabulateSessionsFn(sessions)
+}
+
+func (p *Printer) TabulateBrowsers(browsers []*marks.Browser) ([]string, error) {
+	p.TabulateBrowsersFnCalled = true
+	return p.TabulateBrowsersFn(browsers)
+}
+
+func (p *Printer) TabulateTagCounts(counts []*marks.TagCount) ([]string, error) {
+	p.TabulateTagCountsFnCalled = true
+	return p.TabulateTagCountsFn(counts)
+}
+
+func (p *Printer) TabulateTagTree(nodes []*marks.TagNode) ([]string, error) {
+	p.TabulateTagTreeFnCalled = true
+	return p.TabulateTagTreeFn(nodes)
+}
+
 func (p *Printer) FullMark(m *marks.Mark) (string, error) {
 	p.FullMarkFnCalled = true
 	return p.FullMarkFn(m)
@@ -86,6 +152,36 @@ func (p *Printer) Browser(s string) (string, error) {
 	return p.BrowserFn(s)
 }
 
+func (p *Printer) Record(s string, mks ...*marks.Mark) error {
+	p.RecordFnCalled = true
+	return p.RecordFn(s, mks...)
+}
+
+func (p *Printer) RecordTagCounts(s string, counts []*marks.TagCount) error {
+	p.RecordTagCountsFnCalled = true
+	return p.RecordTagCountsFn(s, counts)
+}
+
+func (p *Printer) RecordTagTree(s string, nodes []*marks.TagNode) error {
+	p.RecordTagTreeFnCalled = true
+	return p.RecordTagTreeFn(s, nodes)
+}
+
+func (p *Printer) RecordSearches(s string, searches []*marks.Search) error {
+	p.RecordSearchesFnCalled = true
+	return p.RecordSearchesFn(s, searches)
+}
+
+func (p *Printer) RecordSessions(s string, sessions []*marks.Session) error {
+	p.RecordSessionsFnCalled = true
+	return p.RecordSessionsFn(s, sessions)
+}
+
+func (p *Printer) RecordBrowsers(s string, browsers []*marks.Browser) error {
+	p.RecordBrowsersFnCalled = true
+	return p.RecordBrowsersFn(s, browsers)
+}
+
 var defaultMsgFn = func(s string, i ...interface{}) {
 	//do nothing
 }
@@ -98,6 +194,30 @@ var defaultTabulateFn = func([]*marks.Mark) ([]string, error) {
 	return []string{}, nil
 }
 
+var defaultTabulateLinkStatusesFn = func([]*marks.LinkStatus) ([]string, error) {
+	return []string{}, nil
+}
+
+var defaultTabulateSearchesFn = func([]*marks.Search) ([]string, error) {
+	return []string{}, nil
+}
+
+var defaultTabulateSessionsFn = func([]*marks.Session) ([]string, error) {
+	return []string{}, nil
+}
+
+var defaultTabulateBrowsersFn = func([]*marks.Browser) ([]string, error) {
+	return []string{}, nil
+}
+
+var defaultTabulateTagCountsFn = func([]*marks.TagCount) ([]string, error) {
+	return []string{}, nil
+}
+
+var defaultTabulateTagTreeFn = func([]*marks.TagNode) ([]string, error) {
+	return []string{}, nil
+}
+
 var defaultFullMarkFn = func(*marks.Mark) (string, error) {
 	return "full mark", nil
 }
@@ -121,3 +241,27 @@ var defaultTagsFn = func(sl []string) (string, error) {
 var defaultBrowserFn = func(s string) (string, error) {
 	return s, nil
 }
+
+var defaultRecordFn = func(string, ...*marks.Mark) error {
+	return nil
+}
+
+var defaultRecordTagCountsFn = func(string, []*marks.TagCount) error {
+	return nil
+}
+
+var defaultRecordTagTreeFn = func(string, []*marks.TagNode) error {
+	return nil
+}
+
+var defaultRecordSearchesFn = func(string, []*marks.Search) error {
+	return nil
+}
+
+var defaultRecordSessionsFn = func(string, []*marks.Session) error {
+	return nil
+}
+
+var defaultRecordBrowsersFn = func(string, []*marks.Browser) error {
+	return nil
+}
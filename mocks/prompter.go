@@ -1,16 +1,22 @@
 package mocks
 
 type Prompter struct {
-	SelectFn        func(string, []string) (int, error)
-	SelectFnCalled  bool
-	ConfirmFn       func(string) bool
-	ConfirmFnCalled bool
+	SelectFn            func(string, []string) (int, error)
+	SelectFnCalled      bool
+	MultiSelectFn       func(string, []string) ([]int, error)
+	MultiSelectFnCalled bool
+	InputFn             func(string) (string, error)
+	InputFnCalled       bool
+	ConfirmFn           func(string) bool
+	ConfirmFnCalled     bool
 }
 
 func NewPrompter() *Prompter {
 	return &Prompter{
-		SelectFn:  defaultSelectFn,
-		ConfirmFn: defaultConfirmFn,
+		SelectFn:      defaultSelectFn,
+		MultiSelectFn: defaultMultiSelectFn,
+		InputFn:       defaultInputFn,
+		ConfirmFn:     defaultConfirmFn,
 	}
 }
 
@@ -23,6 +29,24 @@ var defaultSelectFn = func(label string, table []string) (i int, err error) {
 	return 0, nil
 }
 
+func (p *Prompter) MultiSelect(label string, table []string) ([]int, error) {
+	p.MultiSelectFnCalled = true
+	return p.MultiSelectFn(label, table)
+}
+
+var defaultMultiSelectFn = func(label string, table []string) ([]int, error) {
+	return []int{0}, nil
+}
+
+func (p *Prompter) Input(label string) (string, error) {
+	p.InputFnCalled = true
+	return p.InputFn(label)
+}
+
+var defaultInputFn = func(label string) (string, error) {
+	return "input", nil
+}
+
 func (p *Prompter) Confirm(label string) bool {
 	p.ConfirmFnCalled = true
 	return p.ConfirmFn(label)
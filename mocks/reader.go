@@ -0,0 +1,21 @@
+package mocks
+
+type Reader struct {
+	ReadFileFn       func(string) ([]byte, error)
+	ReadFileFnCalled bool
+}
+
+func NewReader() *Reader {
+	return &Reader{
+		ReadFileFn: defaultReadFileFn,
+	}
+}
+
+func (r *Reader) ReadFile(s string) ([]byte, error) {
+	r.ReadFileFnCalled = true
+	return r.ReadFileFn(s)
+}
+
+var defaultReadFileFn = func(string) ([]byte, error) {
+	return []byte{}, nil
+}
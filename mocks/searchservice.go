@@ -0,0 +1,64 @@
+package mocks
+
+import "github.com/tomguerney/marks/marks"
+
+type SearchService struct {
+	SearchFn         func(name string) (*marks.Search, error)
+	SearchesFn       func() ([]*marks.Search, error)
+	SaveFn           func(s *marks.Search) error
+	DeleteFn         func(name string) error
+	SearchFnCalled   bool
+	SearchesFnCalled bool
+	SaveFnCalled     bool
+	DeleteFnCalled   bool
+}
+
+var DefaultSearches = []*marks.Search{
+	&marks.Search{Name: "daily", Query: "tag:standup url:jira"},
+	&marks.Search{Name: "news", Query: "tag:news"},
+}
+
+func NewSearchService() *SearchService {
+	return &SearchService{
+		SearchFn:   defaultSearchByNameFn,
+		SearchesFn: defaultSearchesFn,
+		SaveFn:     defaultSaveFn,
+		DeleteFn:   defaultDeleteSearchFn,
+	}
+}
+
+var defaultSearchByNameFn = func(name string) (*marks.Search, error) {
+	return nil, nil
+}
+
+var defaultSearchesFn = func() ([]*marks.Search, error) {
+	return DefaultSearches, nil
+}
+
+var defaultSaveFn = func(s *marks.Search) error {
+	return nil
+}
+
+var defaultDeleteSearchFn = func(name string) error {
+	return nil
+}
+
+func (s *SearchService) Search(name string) (*marks.Search, error) {
+	s.SearchFnCalled = true
+	return s.SearchFn(name)
+}
+
+func (s *SearchService) Searches() ([]*marks.Search, error) {
+	s.SearchesFnCalled = true
+	return s.SearchesFn()
+}
+
+func (s *SearchService) Save(search *marks.Search) error {
+	s.SaveFnCalled = true
+	return s.SaveFn(search)
+}
+
+func (s *SearchService) Delete(name string) error {
+	s.DeleteFnCalled = true
+	return s.DeleteFn(name)
+}
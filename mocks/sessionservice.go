@@ -0,0 +1,63 @@
+package mocks
+
+import "github.com/tomguerney/marks/marks"
+
+type SessionService struct {
+	SessionFn        func(name string) (*marks.Session, error)
+	SessionsFn       func() ([]*marks.Session, error)
+	SaveFn           func(s *marks.Session) error
+	DeleteFn         func(name string) error
+	SessionFnCalled  bool
+	SessionsFnCalled bool
+	SaveFnCalled     bool
+	DeleteFnCalled   bool
+}
+
+var DefaultSessions = []*marks.Session{
+	&marks.Session{Name: "morning", Marks: []string{"Abc News", "Google"}},
+}
+
+func NewSessionService() *SessionService {
+	return &SessionService{
+		SessionFn:  defaultSessionFn,
+		SessionsFn: defaultSessionsFn,
+		SaveFn:     defaultSaveSessionFn,
+		DeleteFn:   defaultDeleteSessionFn,
+	}
+}
+
+var defaultSessionFn = func(name string) (*marks.Session, error) {
+	return nil, nil
+}
+
+var defaultSessionsFn = func() ([]*marks.Session, error) {
+	return DefaultSessions, nil
+}
+
+var defaultSaveSessionFn = func(s *marks.Session) error {
+	return nil
+}
+
+var defaultDeleteSessionFn = func(name string) error {
+	return nil
+}
+
+func (s *SessionService) Session(name string) (*marks.Session, error) {
+	s.SessionFnCalled = true
+	return s.SessionFn(name)
+}
+
+func (s *SessionService) Sessions() ([]*marks.Session, error) {
+	s.SessionsFnCalled = true
+	return s.SessionsFn()
+}
+
+func (s *SessionService) Save(session *marks.Session) error {
+	s.SaveFnCalled = true
+	return s.SaveFn(session)
+}
+
+func (s *SessionService) Delete(name string) error {
+	s.DeleteFnCalled = true
+	return s.DeleteFn(name)
+}
@@ -3,9 +3,9 @@ package opener
 import (
 	"errors"
 	"fmt"
-	"html/template"
 	"os/exec"
 	"strings"
+	"text/template"
 
 	"github.com/tomguerney/marks/marks"
 	"github.com/apex/log"
@@ -35,23 +35,26 @@ func NewOpener(config *marks.Config) *opener {
 	return &opener{config: config, commander: &concreteCommander{}}
 }
 
-func (o *opener) Open(url, browser string) error {
-	argTemplate, err := o.template(browser)
+// Open opens the url in the named browser, using profile in place of the
+// browser's own profile if it is given.
+func (o *opener) Open(url, browserName, profile string) error {
+	browser, err := o.browser(browserName)
 	if err != nil {
 		return err
 	}
 
-	argString, err := o.interpolateTemplate(argTemplate, url)
-	if err != nil {
-		return err
+	if profile != "" {
+		withProfile := *browser
+		withProfile.Profile = profile
+		browser = &withProfile
 	}
 
-	argSlice, err := o.sliceArgs(argString)
+	argSlice, err := o.argv(browser, url)
 	if err != nil {
 		return err
 	}
 
-	cmd := o.commander.Command("open", argSlice...)
+	cmd := o.commander.Command(argSlice[0], argSlice[1:]...)
 
 	out, err := cmd.CombinedOutput()
 
@@ -63,25 +66,60 @@ func (o *opener) Open(url, browser string) error {
 	return nil
 }
 
-func (o *opener) template(browser string) (string, error) {
-	switch browser {
-	case "chrome":
-		return o.config.ChromeOpenArgs, nil
-	case "firefox":
-		return o.config.FirefoxOpenArgs, nil
-	default:
-		return "", errors.New(fmt.Sprintf("Browser \"%v\" not supported\n", browser))
+// Validate checks that every browser has a command whose templates can be
+// filled in.
+func (o *opener) Validate() error {
+	for name, browser := range o.config.Browsers {
+		if err := browser.Validate(); err != nil {
+			return errors.New(fmt.Sprintf("Browser \"%v\" is not valid: %v", name, err.Error()))
+		}
+	}
+	return nil
+}
+
+func (o *opener) browser(name string) (*marks.Browser, error) {
+	browser, ok := o.config.Browsers[name]
+	if !ok {
+		return nil, errors.New(fmt.Sprintf("Browser \"%v\" not supported\n", name))
+	}
+	return browser, nil
+}
+
+// argv splits the browser's command line before interpolating so the url
+// stays a single argument whatever characters it contains. An argument that
+// its template leaves empty, such as "{{if .Profile}}...{{end}}" without a
+// profile, is left out.
+func (o *opener) argv(browser *marks.Browser, url string) ([]string, error) {
+	argTemplates, err := o.sliceArgs(browser.CommandLine())
+	if err != nil {
+		return nil, err
 	}
 
+	if len(argTemplates) == 0 {
+		return nil, errors.New(fmt.Sprintf("Browser \"%v\" has no command to open it\n", browser.Name))
+	}
+
+	argSlice := []string{}
+	for _, argTemplate := range argTemplates {
+		arg, err := o.interpolateTemplate(argTemplate, browser, url)
+		if err != nil {
+			return nil, err
+		}
+		if arg == "" && strings.Contains(argTemplate, "{{") {
+			continue
+		}
+		argSlice = append(argSlice, arg)
+	}
+	return argSlice, nil
 }
 
-func (o *opener) interpolateTemplate(argTemplate, url string) (string, error) {
+func (o *opener) interpolateTemplate(argTemplate string, browser *marks.Browser, url string) (string, error) {
 	builder := strings.Builder{}
 	tmpl, err := template.New("open").Parse(argTemplate)
 	if err != nil {
 		return "", err
 	}
-	data := struct{ Url string }{Url: url}
+	data := struct{ Url, Profile string }{Url: url, Profile: browser.Profile}
 	err = tmpl.Execute(&builder, data)
 	if err != nil {
 		return "", err
@@ -5,14 +5,21 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/tomguerney/marks/marks"
 	"github.com/tomguerney/marks/mocks"
 )
 
 func newTestOpener() *opener {
-	return &opener{
+	o := &opener{
 		config:    mocks.NewConfig(),
 		commander: newMockCommander(),
 	}
+	o.config.Browsers = map[string]*marks.Browser{
+		"chrome":  &marks.Browser{Name: "chrome"},
+		"firefox": &marks.Browser{Name: "firefox"},
+		"default": &marks.Browser{Name: "default"},
+	}
+	return o
 }
 
 type mockCommmander struct {
@@ -64,7 +71,7 @@ func TestOpenSuccess(t *testing.T) {
 	o := newTestOpener()
 	url := "https://www.url.com"
 	browser := "chrome"
-	o.config.ChromeOpenArgs = "-a \"Google Chrome\" {{.Url}}"
+	o.config.Browsers["chrome"].Command = "open -a \"Google Chrome\" {{.Url}}"
 	commandFn := func(actualName string, actualArgs ...string) combinedOutputter {
 		expectedName := "open"
 		expectedArgs := []string{"-a", "Google Chrome", url}
@@ -77,7 +84,7 @@ func TestOpenSuccess(t *testing.T) {
 		return nil
 	}
 	o.commander.(*mockCommmander).commandFn = commandFn
-	err := o.Open(url, browser)
+	err := o.Open(url, browser, "")
 	if err != nil {
 		t.Fatal(err.Error())
 	}
@@ -86,11 +93,44 @@ func TestOpenSuccess(t *testing.T) {
 	}
 }
 
+func TestOpenWithLauncher(t *testing.T) {
+	o := newTestOpener()
+	url := "https://www.url.com/search?q=a b&lang=en"
+	o.config.Browsers["default"].Command = "xdg-open {{.Url}}"
+	commandFn := func(actualName string, actualArgs ...string) combinedOutputter {
+		if actualName != "xdg-open" {
+			t.Fatalf("expected xdg-open, received %v", actualName)
+		}
+		if !reflect.DeepEqual(actualArgs, []string{url}) {
+			t.Fatalf("expected %v, received %v", []string{url}, actualArgs)
+		}
+		return nil
+	}
+	o.commander.(*mockCommmander).commandFn = commandFn
+	if err := o.Open(url, "default", ""); err != nil {
+		t.Fatal(err.Error())
+	}
+	if !o.commander.(*mockCommmander).commandFnCalled {
+		t.Fatal("Command should have been called")
+	}
+}
+
+func TestOpenWithoutCommand(t *testing.T) {
+	o := newTestOpener()
+	err := o.Open("https://www.url.com", "firefox", "")
+	if err == nil {
+		t.Fatal("should return error")
+	}
+	if o.commander.(*mockCommmander).commandFnCalled {
+		t.Fatal("Command should not have been called")
+	}
+}
+
 func TestOpenTemplateFail(t *testing.T) {
 	o := newTestOpener()
 	url := "https://www.url.com"
 	browser := "not a browser"
-	err := o.Open(url, browser)
+	err := o.Open(url, browser, "")
 	t.Log("Expected error: ", err.Error())
 	if err == nil {
 		t.Fatal("should return error")
@@ -102,10 +142,10 @@ func TestOpenTemplateFail(t *testing.T) {
 
 func TestOpenInterplateFail(t *testing.T) {
 	o := newTestOpener()
-	o.config.ChromeOpenArgs = "{{.Notafield}}"
+	o.config.Browsers["chrome"].Command = "open {{.Notafield}}"
 	url := "https://www.url.com"
 	browser := "chrome"
-	err := o.Open(url, browser)
+	err := o.Open(url, browser, "")
 	t.Log("Expected error: ", err.Error())
 	if err == nil {
 		t.Fatal("should return error")
@@ -119,12 +159,13 @@ func TestOpenCombinedOutputFail(t *testing.T) {
 	o := newTestOpener()
 	url := "https://www.url.com"
 	browser := "chrome"
+	o.config.Browsers["chrome"].Command = "google-chrome {{.Url}}"
 	combinedOutputFn := func() ([]byte, error) {
 		return nil, errors.New("combined output error")
 	}
 	o.commander.(*mockCommmander).
 		combinedOutputter.(*mockCombinedOutputter).combinedOutputFn = combinedOutputFn
-	err := o.Open(url, browser)
+	err := o.Open(url, browser, "")
 	t.Log("Expected error: ", err.Error())
 	if err == nil {
 		t.Fatal("should return error")
@@ -135,11 +176,79 @@ func TestOpenCombinedOutputFail(t *testing.T) {
 	}
 }
 
-func TestTemplateSuccess(t *testing.T) {
+func TestOpenWithProfile(t *testing.T) {
+	o := newTestOpener()
+	url := "https://www.url.com"
+	o.config.Browsers["brave"] = &marks.Browser{
+		Name:    "brave",
+		Command: "brave-browser",
+		Args:    "--profile-directory={{.Profile}} {{.Url}}",
+		Profile: "Profile 1",
+	}
+	commandFn := func(actualName string, actualArgs ...string) combinedOutputter {
+		expectedArgs := []string{"--profile-directory=Profile 1", url}
+		if actualName != "brave-browser" {
+			t.Fatalf("expected brave-browser, received %v", actualName)
+		}
+		if !reflect.DeepEqual(actualArgs, expectedArgs) {
+			t.Fatalf("expected %v, received %v", expectedArgs, actualArgs)
+		}
+		return nil
+	}
+	o.commander.(*mockCommmander).commandFn = commandFn
+	if err := o.Open(url, "brave", ""); err != nil {
+		t.Fatal(err.Error())
+	}
+}
+
+func TestOpenWithProfileOverride(t *testing.T) {
 	o := newTestOpener()
-	expected := "firefox args"
-	o.config.FirefoxOpenArgs = expected
-	actual, err := o.template("firefox")
+	o.config.Browsers["chrome"] = &marks.Browser{
+		Name:    "chrome",
+		Command: "google-chrome --profile-directory={{.Profile}} {{.Url}}",
+		Profile: "Default",
+	}
+	commandFn := func(actualName string, actualArgs ...string) combinedOutputter {
+		if actualArgs[0] != "--profile-directory=Work" {
+			t.Fatalf("expected the Work profile, received %v", actualArgs)
+		}
+		return nil
+	}
+	o.commander.(*mockCommmander).commandFn = commandFn
+	if err := o.Open("https://www.url.com", "chrome", "Work"); err != nil {
+		t.Fatal(err.Error())
+	}
+	if o.config.Browsers["chrome"].Profile != "Default" {
+		t.Fatal("the configured profile should not change")
+	}
+}
+
+func TestOpenLeavesOutEmptyProfile(t *testing.T) {
+	o := newTestOpener()
+	o.config.Browsers["chrome"].Command = "google-chrome \"{{if .Profile}}--profile-directory={{.Profile}}{{end}}\" {{.Url}}"
+	var args []string
+	o.commander.(*mockCommmander).commandFn = func(name string, actualArgs ...string) combinedOutputter {
+		args = actualArgs
+		return nil
+	}
+	if err := o.Open("https://www.url.com", "chrome", ""); err != nil {
+		t.Fatal(err.Error())
+	}
+	if expected := []string{"https://www.url.com"}; !reflect.DeepEqual(args, expected) {
+		t.Fatalf("expected %v, received %v", expected, args)
+	}
+	if err := o.Open("https://www.url.com", "chrome", "Work"); err != nil {
+		t.Fatal(err.Error())
+	}
+	if expected := []string{"--profile-directory=Work", "https://www.url.com"}; !reflect.DeepEqual(args, expected) {
+		t.Fatalf("expected %v, received %v", expected, args)
+	}
+}
+
+func TestBrowserSuccess(t *testing.T) {
+	o := newTestOpener()
+	expected := o.config.Browsers["firefox"]
+	actual, err := o.browser("firefox")
 	if err != nil {
 		t.Fatal(err.Error())
 	}
@@ -148,25 +257,46 @@ func TestTemplateSuccess(t *testing.T) {
 	}
 }
 
-func TestTemplateFail(t *testing.T) {
+func TestBrowserFail(t *testing.T) {
 	o := newTestOpener()
-	expected := ""
-	actual, err := o.template("not a browser")
+	actual, err := o.browser("not a browser")
 	if err == nil {
 		t.Fatal("expected error")
 	}
 	t.Log("Expected error: ", err.Error())
-	if actual != "" {
-		t.Fatalf("expected %v, received %v", expected, actual)
+	if actual != nil {
+		t.Fatalf("expected nil, received %v", actual)
 	}
 }
 
+func TestValidateSuccess(t *testing.T) {
+	o := newTestOpener()
+	for _, browser := range o.config.Browsers {
+		browser.Command = "xdg-open {{.Url}}"
+	}
+	if err := o.Validate(); err != nil {
+		t.Fatal(err.Error())
+	}
+}
+
+func TestValidateFail(t *testing.T) {
+	o := newTestOpener()
+	o.config.Browsers = map[string]*marks.Browser{
+		"lynx": &marks.Browser{Name: "lynx", Command: "lynx", Args: "{{.Address}}"},
+	}
+	err := o.Validate()
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	t.Log("Expected error: ", err.Error())
+}
+
 func TestInterpolateTemplateSuccess(t *testing.T) {
 	o := newTestOpener()
 	url := "testUrl"
 	expected := "***testUrl***"
 	template := "***{{.Url}}***"
-	actual, err := o.interpolateTemplate(template, url)
+	actual, err := o.interpolateTemplate(template, &marks.Browser{}, url)
 	if err != nil {
 		t.Fatal(err.Error())
 	}
@@ -179,7 +309,7 @@ func TestInterpolateTemplateFail(t *testing.T) {
 	o := newTestOpener()
 	url := "testUrl"
 	template := "***{{.Other}}***"
-	actual, err := o.interpolateTemplate(template, url)
+	actual, err := o.interpolateTemplate(template, &marks.Browser{}, url)
 	if err == nil {
 		t.Fatal("expected error")
 	}
@@ -0,0 +1,224 @@
+package printer
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tomguerney/marks/marks"
+	"gopkg.in/yaml.v2"
+)
+
+// formatPrinter writes machine-readable records to out. Human-readable
+// messages are discarded and errors are written to errOut so that out
+// can be piped straight into other tools.
+type formatPrinter struct {
+	*printer
+	out    io.Writer
+	errOut io.Writer
+	format string
+}
+
+type record struct {
+	Action string        `json:"action" yaml:"action"`
+	Marks  []*marks.Mark `json:"marks" yaml:"marks"`
+}
+
+var recordHeader = []string{"action", "id", "url", "tags", "created", "updated", "lastOpened", "visits", "description", "notes", "browser"}
+
+type tagCountRecord struct {
+	Action string            `json:"action" yaml:"action"`
+	Tags   []*marks.TagCount `json:"tags" yaml:"tags"`
+}
+
+var tagCountHeader = []string{"action", "tag", "count"}
+
+type tagTreeRecord struct {
+	Action string           `json:"action" yaml:"action"`
+	Tags   []*marks.TagNode `json:"tags" yaml:"tags"`
+}
+
+var tagTreeHeader = []string{"action", "path", "count"}
+
+type searchRecord struct {
+	Action   string          `json:"action" yaml:"action"`
+	Searches []*marks.Search `json:"searches" yaml:"searches"`
+}
+
+var searchHeader = []string{"action", "name", "query"}
+
+type sessionRecord struct {
+	Action   string           `json:"action" yaml:"action"`
+	Sessions []*marks.Session `json:"sessions" yaml:"sessions"`
+}
+
+var sessionHeader = []string{"action", "name", "marks"}
+
+type browserRecord struct {
+	Action   string           `json:"action" yaml:"action"`
+	Browsers []*marks.Browser `json:"browsers" yaml:"browsers"`
+}
+
+var browserHeader = []string{"action", "name", "command", "args", "profile"}
+
+func NewFormatPrinter(config *marks.Config, colorizer colorizer) *formatPrinter {
+	return &formatPrinter{
+		&printer{ioutil.Discard, config, colorizer},
+		os.Stdout,
+		os.Stderr,
+		config.Output,
+	}
+}
+
+func (p *formatPrinter) Error(text string, a ...interface{}) {
+	textln := fmt.Sprintf("Error: %s\n", text)
+	fmt.Fprintf(p.errOut, textln, a...)
+}
+
+func (p *formatPrinter) Record(action string, mks ...*marks.Mark) error {
+	if mks == nil {
+		mks = []*marks.Mark{}
+	}
+	rows := [][]string{}
+	for _, m := range mks {
+		rows = append(rows, []string{
+			action,
+			m.Id,
+			m.Url,
+			strings.Join(m.Tags, ","),
+			formatRecordTime(m.CreatedAt()),
+			formatRecordTime(m.UpdatedAt()),
+			formatRecordTime(m.LastOpenedAt()),
+			strconv.Itoa(m.Visits),
+			m.Description,
+			m.Notes,
+			m.Browser,
+		})
+	}
+	return p.write(&record{Action: action, Marks: mks}, recordHeader, rows)
+}
+
+func (p *formatPrinter) RecordTagCounts(action string, counts []*marks.TagCount) error {
+	if counts == nil {
+		counts = []*marks.TagCount{}
+	}
+	rows := [][]string{}
+	for _, count := range counts {
+		rows = append(rows, []string{action, count.Tag, strconv.Itoa(count.Count)})
+	}
+	return p.write(&tagCountRecord{Action: action, Tags: counts}, tagCountHeader, rows)
+}
+
+// RecordTagTree writes the tree as nested nodes, or for csv and tsv as one
+// row per node in the order the tree is drawn.
+func (p *formatPrinter) RecordTagTree(action string, nodes []*marks.TagNode) error {
+	if nodes == nil {
+		nodes = []*marks.TagNode{}
+	}
+	rows := [][]string{}
+	for _, node := range flattenTagTree(nodes) {
+		rows = append(rows, []string{action, node.Path, strconv.Itoa(node.Count)})
+	}
+	return p.write(&tagTreeRecord{Action: action, Tags: nodes}, tagTreeHeader, rows)
+}
+
+func (p *formatPrinter) RecordSearches(action string, searches []*marks.Search) error {
+	if searches == nil {
+		searches = []*marks.Search{}
+	}
+	rows := [][]string{}
+	for _, search := range searches {
+		rows = append(rows, []string{action, search.Name, search.Query})
+	}
+	return p.write(&searchRecord{Action: action, Searches: searches}, searchHeader, rows)
+}
+
+func (p *formatPrinter) RecordSessions(action string, sessions []*marks.Session) error {
+	if sessions == nil {
+		sessions = []*marks.Session{}
+	}
+	rows := [][]string{}
+	for _, session := range sessions {
+		rows = append(rows, []string{action, session.Name, strings.Join(session.Marks, ",")})
+	}
+	return p.write(&sessionRecord{Action: action, Sessions: sessions}, sessionHeader, rows)
+}
+
+func (p *formatPrinter) RecordBrowsers(action string, browsers []*marks.Browser) error {
+	if browsers == nil {
+		browsers = []*marks.Browser{}
+	}
+	rows := [][]string{}
+	for _, browser := range browsers {
+		rows = append(rows, []string{action, browser.Name, browser.Command, browser.Args, browser.Profile})
+	}
+	return p.write(&browserRecord{Action: action, Browsers: browsers}, browserHeader, rows)
+}
+
+// write writes r as json or yaml, or the header and rows as csv or tsv.
+func (p *formatPrinter) write(r interface{}, header []string, rows [][]string) error {
+	switch p.format {
+	case "json":
+		return p.json(r)
+	case "yaml":
+		return p.yaml(r)
+	case "csv":
+		return p.delimited(header, rows, ',')
+	case "tsv":
+		return p.delimited(header, rows, '\t')
+	default:
+		return errors.New(fmt.Sprintf("output format \"%v\" not supported", p.format))
+	}
+}
+
+func (p *formatPrinter) json(r interface{}) error {
+	return json.NewEncoder(p.out).Encode(r)
+}
+
+func (p *formatPrinter) yaml(r interface{}) error {
+	out, err := yaml.Marshal(r)
+	if err != nil {
+		return err
+	}
+	_, err = p.out.Write(out)
+	return err
+}
+
+func (p *formatPrinter) delimited(header []string, rows [][]string, delimiter rune) error {
+	writer := csv.NewWriter(p.out)
+	writer.Comma = delimiter
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func formatRecordTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+// flattenTagTree lists the nodes depth first, each before its children.
+func flattenTagTree(nodes []*marks.TagNode) []*marks.TagNode {
+	flattened := []*marks.TagNode{}
+	for _, node := range nodes {
+		flattened = append(flattened, node)
+		flattened = append(flattened, flattenTagTree(node.Children)...)
+	}
+	return flattened
+}
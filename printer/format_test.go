@@ -0,0 +1,211 @@
+package printer
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tomguerney/marks/marks"
+	"github.com/tomguerney/marks/mocks"
+)
+
+func newTestFormatPrinter(format string) (*formatPrinter, *strings.Builder) {
+	out := &strings.Builder{}
+	return &formatPrinter{NewTestPrinter(), out, &strings.Builder{}, format}, out
+}
+
+var testRecordMark = &marks.Mark{
+	Id:      "Abc News",
+	Url:     "https://www.abc.net.au/news/",
+	Tags:    []string{"news", "current affairs"},
+	Created: marks.Timestamp(time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)),
+}
+
+func TestRecordJson(t *testing.T) {
+	p, out := newTestFormatPrinter("json")
+	if err := p.Record("add", testRecordMark); err != nil {
+		t.Fatal(err.Error())
+	}
+	expected := `{"action":"add","marks":[{"id":"Abc News","url":"https://www.abc.net.au/news/","tags":["news","current affairs"],"created":"2025-01-02T03:04:05Z"}]}` + "\n"
+	if out.String() != expected {
+		t.Fatalf("expected %v, received %v", expected, out.String())
+	}
+}
+
+func TestRecordJsonWithNoMarks(t *testing.T) {
+	p, out := newTestFormatPrinter("json")
+	if err := p.Record("list"); err != nil {
+		t.Fatal(err.Error())
+	}
+	expected := `{"action":"list","marks":[]}` + "\n"
+	if out.String() != expected {
+		t.Fatalf("expected %v, received %v", expected, out.String())
+	}
+}
+
+func TestRecordYaml(t *testing.T) {
+	p, out := newTestFormatPrinter("yaml")
+	if err := p.Record("add", testRecordMark); err != nil {
+		t.Fatal(err.Error())
+	}
+	expected := getMultilineString([]string{
+		"action: add",
+		"marks:",
+		"- id: Abc News",
+		"  url: https://www.abc.net.au/news/",
+		"  tags:",
+		"  - news",
+		"  - current affairs",
+		"  created: 2025-01-02T03:04:05Z",
+	})
+	if out.String() != expected {
+		t.Fatalf("expected %v, received %v", expected, out.String())
+	}
+}
+
+func TestRecordCsv(t *testing.T) {
+	p, out := newTestFormatPrinter("csv")
+	if err := p.Record("list", testRecordMark, mocks.DefaultMarks[1]); err != nil {
+		t.Fatal(err.Error())
+	}
+	expected := getMultilineString([]string{
+		"action,id,url,tags,created,updated,lastOpened,visits,description,notes,browser",
+		"list,Abc News,https://www.abc.net.au/news/,\"news,current affairs\",2025-01-02T03:04:05Z,,,0,,,",
+		"list,Google,https://www.google.com,search,,,,0,,,",
+	})
+	if out.String() != expected {
+		t.Fatalf("expected %v, received %v", expected, out.String())
+	}
+}
+
+func TestRecordTsv(t *testing.T) {
+	p, out := newTestFormatPrinter("tsv")
+	if err := p.Record("list", testRecordMark); err != nil {
+		t.Fatal(err.Error())
+	}
+	expected := getMultilineString([]string{
+		"action\tid\turl\ttags\tcreated\tupdated\tlastOpened\tvisits\tdescription\tnotes\tbrowser",
+		"list\tAbc News\thttps://www.abc.net.au/news/\tnews,current affairs\t2025-01-02T03:04:05Z\t\t\t0\t\t\t",
+	})
+	if out.String() != expected {
+		t.Fatalf("expected %v, received %v", expected, out.String())
+	}
+}
+
+func TestRecordTagCountsJson(t *testing.T) {
+	p, out := newTestFormatPrinter("json")
+	counts := []*marks.TagCount{&marks.TagCount{Tag: "news", Count: 2}}
+	if err := p.RecordTagCounts("tags list", counts); err != nil {
+		t.Fatal(err.Error())
+	}
+	expected := `{"action":"tags list","tags":[{"tag":"news","count":2}]}` + "\n"
+	if out.String() != expected {
+		t.Fatalf("expected %v, received %v", expected, out.String())
+	}
+}
+
+func TestRecordTagTreeCsv(t *testing.T) {
+	p, out := newTestFormatPrinter("csv")
+	tree := []*marks.TagNode{
+		&marks.TagNode{Name: "work", Path: "work", Count: 2, Children: []*marks.TagNode{
+			&marks.TagNode{Name: "admin", Path: "work/admin", Count: 1},
+		}},
+		&marks.TagNode{Name: "news", Path: "news", Count: 1},
+	}
+	if err := p.RecordTagTree("tags tree", tree); err != nil {
+		t.Fatal(err.Error())
+	}
+	expected := getMultilineString([]string{
+		"action,path,count",
+		"tags tree,work,2",
+		"tags tree,work/admin,1",
+		"tags tree,news,1",
+	})
+	if out.String() != expected {
+		t.Fatalf("expected %v, received %v", expected, out.String())
+	}
+}
+
+func TestRecordSearchesYaml(t *testing.T) {
+	p, out := newTestFormatPrinter("yaml")
+	searches := []*marks.Search{&marks.Search{Name: "daily", Query: "tag:standup"}}
+	if err := p.RecordSearches("search list", searches); err != nil {
+		t.Fatal(err.Error())
+	}
+	expected := getMultilineString([]string{
+		"action: search list",
+		"searches:",
+		"- name: daily",
+		"  query: tag:standup",
+	})
+	if out.String() != expected {
+		t.Fatalf("expected %v, received %v", expected, out.String())
+	}
+}
+
+func TestRecordSessionsCsv(t *testing.T) {
+	p, out := newTestFormatPrinter("csv")
+	sessions := []*marks.Session{&marks.Session{Name: "morning", Marks: []string{"jira", "slack"}}}
+	if err := p.RecordSessions("session list", sessions); err != nil {
+		t.Fatal(err.Error())
+	}
+	expected := getMultilineString([]string{
+		"action,name,marks",
+		"session list,morning,\"jira,slack\"",
+	})
+	if out.String() != expected {
+		t.Fatalf("expected %v, received %v", expected, out.String())
+	}
+}
+
+func TestRecordBrowsersCsv(t *testing.T) {
+	p, out := newTestFormatPrinter("csv")
+	browsers := []*marks.Browser{&marks.Browser{Name: "lynx", Command: "lynx", Args: "{{.Url}}"}}
+	if err := p.RecordBrowsers("browsers", browsers); err != nil {
+		t.Fatal(err.Error())
+	}
+	expected := getMultilineString([]string{
+		"action,name,command,args,profile",
+		"browsers,lynx,lynx,{{.Url}},",
+	})
+	if out.String() != expected {
+		t.Fatalf("expected %v, received %v", expected, out.String())
+	}
+}
+
+func TestRecordUnsupportedFormat(t *testing.T) {
+	p, _ := newTestFormatPrinter("not a format")
+	if err := p.Record("list", testRecordMark); err == nil {
+		t.Fatal("Record should return error")
+	}
+}
+
+func TestFormatPrinterDiscardsMessages(t *testing.T) {
+	config := mocks.NewConfig()
+	config.Output = "json"
+	p := NewFormatPrinter(config, mocks.NewColorizer())
+	if p.printer.out != ioutil.Discard {
+		t.Fatal("messages should be written to a discard writer")
+	}
+	out, errOut := &strings.Builder{}, &strings.Builder{}
+	p.out, p.errOut = out, errOut
+	p.Msg("message")
+	if out.Len() != 0 || errOut.Len() != 0 {
+		t.Fatalf("expected no output, received %v%v", out.String(), errOut.String())
+	}
+}
+
+func TestFormatPrinterError(t *testing.T) {
+	p, out := newTestFormatPrinter("json")
+	errOut := &strings.Builder{}
+	p.errOut = errOut
+	p.Error("error %v", "arg")
+	if out.Len() != 0 {
+		t.Fatalf("expected no output, received %v", out.String())
+	}
+	expected := "Error: error arg\n"
+	if errOut.String() != expected {
+		t.Fatalf("expected %v, received %v", expected, errOut.String())
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"strings"
 	"text/tabwriter"
+	"time"
 
 	"github.com/tomguerney/marks/marks"
 )
@@ -52,8 +53,92 @@ func (p *printer) Tabulate(mks []*marks.Mark) ([]string, error) {
 	builder := strings.Builder{}
 	writer := tabwriter.NewWriter(&builder, 0, 8, 4, ' ', 0)
 
-	for _, printM := range printMks {
-		fmt.Fprintln(writer, fmt.Sprintf("%s", strings.Join(printM.split(), "\t")))
+	for i, printM := range printMks {
+		row := printM.split()
+		if p.config.ShowTimestamps {
+			row = append(row, timestamps(mks[i])...)
+		}
+		fmt.Fprintln(writer, fmt.Sprintf("%s", strings.Join(row, "\t")))
+	}
+
+	writer.Flush()
+	table := strings.Split(builder.String(), "\n")
+
+	return table[:len(table)-1], nil
+}
+
+func (p *printer) TabulateLinkStatuses(statuses []*marks.LinkStatus) ([]string, error) {
+	builder := strings.Builder{}
+	writer := tabwriter.NewWriter(&builder, 0, 8, 4, ' ', 0)
+
+	for _, status := range statuses {
+		id, err := p.Id(status.Mark.Id)
+		if err != nil {
+			return nil, err
+		}
+		url, err := p.Url(status.Mark.Url)
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintln(writer, strings.Join([]string{p.linkStatus(status), id, url, p.linkDetail(status)}, "\t"))
+	}
+
+	writer.Flush()
+	table := strings.Split(builder.String(), "\n")
+
+	return table[:len(table)-1], nil
+}
+
+func (p *printer) TabulateSearches(searches []*marks.Search) ([]string, error) {
+	builder := strings.Builder{}
+	writer := tabwriter.NewWriter(&builder, 0, 8, 4, ' ', 0)
+
+	for _, search := range searches {
+		name, err := p.Id(fmt.Sprintf("@%v", search.Name))
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintln(writer, strings.Join([]string{name, search.Query}, "\t"))
+	}
+
+	writer.Flush()
+	table := strings.Split(builder.String(), "\n")
+
+	return table[:len(table)-1], nil
+}
+
+func (p *printer) TabulateSessions(sessions []*marks.Session) ([]string, error) {
+	builder := strings.Builder{}
+	writer := tabwriter.NewWriter(&builder, 0, 8, 4, ' ', 0)
+
+	for _, session := range sessions {
+		name, err := p.Id(session.Name)
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintln(writer, strings.Join([]string{name, strings.Join(session.Marks, ", ")}, "\t"))
+	}
+
+	writer.Flush()
+	table := strings.Split(builder.String(), "\n")
+
+	return table[:len(table)-1], nil
+}
+
+func (p *printer) TabulateBrowsers(browsers []*marks.Browser) ([]string, error) {
+	builder := strings.Builder{}
+	writer := tabwriter.NewWriter(&builder, 0, 8, 4, ' ', 0)
+
+	for _, browser := range browsers {
+		name, err := p.Browser(browser.Name)
+		if err != nil {
+			return nil, err
+		}
+		row := []string{name, browser.CommandLine()}
+		if browser.Profile != "" {
+			row = append(row, browser.Profile)
+		}
+		fmt.Fprintln(writer, strings.Join(row, "\t"))
 	}
 
 	writer.Flush()
@@ -62,6 +147,75 @@ func (p *printer) Tabulate(mks []*marks.Mark) ([]string, error) {
 	return table[:len(table)-1], nil
 }
 
+func (p *printer) TabulateTagCounts(counts []*marks.TagCount) ([]string, error) {
+	builder := strings.Builder{}
+	writer := tabwriter.NewWriter(&builder, 0, 8, 4, ' ', 0)
+
+	for _, count := range counts {
+		tag, err := p.colorizer.Colorize(p.config.TagsColor, count.Tag)
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintln(writer, fmt.Sprintf("%v\t%v", tag, count.Count))
+	}
+
+	writer.Flush()
+	table := strings.Split(builder.String(), "\n")
+
+	return table[:len(table)-1], nil
+}
+
+// TabulateTagTree draws the tag hierarchy with the number of marks beneath
+// each tag.
+func (p *printer) TabulateTagTree(nodes []*marks.TagNode) ([]string, error) {
+	return p.tagTree(nodes, "", true)
+}
+
+func (p *printer) tagTree(nodes []*marks.TagNode, indent string, top bool) ([]string, error) {
+	lines := []string{}
+	for i, node := range nodes {
+		name, err := p.colorizer.Colorize(p.config.TagsColor, node.Name)
+		if err != nil {
+			return nil, err
+		}
+		branch, childIndent := "├── ", indent+"│   "
+		if i == len(nodes)-1 {
+			branch, childIndent = "└── ", indent+"    "
+		}
+		if top {
+			branch, childIndent = "", ""
+		}
+		lines = append(lines, fmt.Sprintf("%v%v%v (%v)", indent, branch, name, node.Count))
+		children, err := p.tagTree(node.Children, childIndent, false)
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, children...)
+	}
+	return lines, nil
+}
+
+func (p *printer) linkStatus(status *marks.LinkStatus) string {
+	text := "error"
+	if status.Err == nil {
+		text = fmt.Sprintf("%v", status.StatusCode)
+	}
+	if status.Broken() {
+		return p.colorizer.Red(text)
+	}
+	return p.colorizer.Green(text)
+}
+
+func (p *printer) linkDetail(status *marks.LinkStatus) string {
+	if status.Err != nil {
+		return status.Err.Error()
+	}
+	if status.Redirected() {
+		return fmt.Sprintf("-> %v", status.Location)
+	}
+	return ""
+}
+
 func (p *printer) FullMark(m *marks.Mark) (string, error) {
 	pm, err := p.colorize(m)
 	if err != nil {
@@ -80,7 +234,21 @@ func (p *printer) FullMark(m *marks.Mark) (string, error) {
 		output = append(output, fmt.Sprintf("%v", pm.tags))
 	}
 
-	return strings.Join(output, " "), nil
+	if p.config.ShowTimestamps {
+		for _, timestamp := range timestamps(m) {
+			if timestamp != "" {
+				output = append(output, timestamp)
+			}
+		}
+	}
+
+	full := strings.Join(output, " ")
+
+	if m.Notes != "" {
+		full = fmt.Sprintf("%v\n%v", full, indent(m.Notes))
+	}
+
+	return full, nil
 }
 
 func (p *printer) FullMarkWithFields(m *marks.Mark) (string, error) {
@@ -101,6 +269,30 @@ func (p *printer) FullMarkWithFields(m *marks.Mark) (string, error) {
 		output = append(output, fmt.Sprintf("Tags: %v", pm.tags))
 	}
 
+	if m.Description != "" {
+		output = append(output, fmt.Sprintf("Description: %v", m.Description))
+	}
+
+	if m.Browser != "" {
+		output = append(output, fmt.Sprintf("Browser: %v", m.Browser))
+	}
+
+	if m.Notes != "" {
+		output = append(output, fmt.Sprintf("Notes:\n%v", indent(m.Notes)))
+	}
+
+	if p.config.ShowTimestamps {
+		if !m.CreatedAt().IsZero() {
+			output = append(output, fmt.Sprintf("Created: %v", formatTime(m.CreatedAt())))
+		}
+		if !m.UpdatedAt().IsZero() {
+			output = append(output, fmt.Sprintf("Updated: %v", formatTime(m.UpdatedAt())))
+		}
+		if !m.LastOpenedAt().IsZero() {
+			output = append(output, fmt.Sprintf("Last opened: %v", formatTime(m.LastOpenedAt())))
+		}
+	}
+
 	return strings.Join(output, ", "), nil
 }
 
@@ -154,3 +346,57 @@ func (p *printer) colorize(m *marks.Mark) (*printMark, error) {
 		tags: tags,
 	}, nil
 }
+
+// timestamps returns a labelled cell for each of the mark's timestamps,
+// leaving cells empty for those that are unset so table columns align.
+func timestamps(m *marks.Mark) []string {
+	labelled := func(label string, t time.Time) string {
+		if t.IsZero() {
+			return ""
+		}
+		return fmt.Sprintf("%v %v", label, formatTime(t))
+	}
+	return []string{
+		labelled("created", m.CreatedAt()),
+		labelled("updated", m.UpdatedAt()),
+		labelled("opened", m.LastOpenedAt()),
+	}
+}
+
+func formatTime(t time.Time) string {
+	return t.Local().Format("2006-01-02 15:04")
+}
+
+func (p *printer) Record(action string, mks ...*marks.Mark) error {
+	return nil
+}
+
+func (p *printer) RecordTagCounts(action string, counts []*marks.TagCount) error {
+	return nil
+}
+
+func (p *printer) RecordTagTree(action string, nodes []*marks.TagNode) error {
+	return nil
+}
+
+func (p *printer) RecordSearches(action string, searches []*marks.Search) error {
+	return nil
+}
+
+func (p *printer) RecordSessions(action string, sessions []*marks.Session) error {
+	return nil
+}
+
+func (p *printer) RecordBrowsers(action string, browsers []*marks.Browser) error {
+	return nil
+}
+
+// indent prefixes each line of multi-line text so it sits beneath the line
+// it belongs to.
+func indent(text string) string {
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = "  " + line
+	}
+	return strings.Join(lines, "\n")
+}
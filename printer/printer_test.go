@@ -6,6 +6,7 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/tomguerney/marks/marks"
 
@@ -379,6 +380,27 @@ func TestFullMark(t *testing.T) {
 	}
 }
 
+func TestFullMarkWithTimestamps(t *testing.T) {
+	m := &marks.Mark{
+		Id:         "Abc News",
+		Created:    marks.Timestamp(time.Date(2025, 1, 2, 3, 4, 0, 0, time.Local)),
+		LastOpened: marks.Timestamp(time.Date(2025, 2, 3, 4, 5, 0, 0, time.Local)),
+	}
+	p := NewTestPrinter()
+	p.config.ShowTimestamps = true
+	p.colorizer.(*mocks.Colorizer).ColorizeFn = func(colorName, text string) (string, error) {
+		return fmt.Sprintf("colorized[%v]", text), nil
+	}
+	actual, err := p.FullMark(m)
+	if err != nil {
+		t.Fatalf("should not return error")
+	}
+	expected := "colorized[Abc News] created 2025-01-02 03:04 opened 2025-02-03 04:05"
+	if expected != actual {
+		t.Fatalf("expected %v, received %v", expected, actual)
+	}
+}
+
 func TestFullMarkNoUrl(t *testing.T) {
 	m := &marks.Mark{
 		Id:   "Abc News",
@@ -417,6 +439,25 @@ func TestFullMarkNoTags(t *testing.T) {
 	}
 }
 
+func TestFullMarkWithNotes(t *testing.T) {
+	m := &marks.Mark{
+		Id:    "Abc News",
+		Notes: "Saved for the election coverage\nCheck the live blog\n",
+	}
+	p := NewTestPrinter()
+	p.colorizer.(*mocks.Colorizer).ColorizeFn = func(colorName, text string) (string, error) {
+		return fmt.Sprintf("colorized[%v]", text), nil
+	}
+	actual, err := p.FullMark(m)
+	if err != nil {
+		t.Fatalf("should not return error")
+	}
+	expected := "colorized[Abc News]\n  Saved for the election coverage\n  Check the live blog"
+	if expected != actual {
+		t.Fatalf("expected %q, received %q", expected, actual)
+	}
+}
+
 func TestFullMarkWithFields(t *testing.T) {
 	m := &marks.Mark{
 		Id:   "Abc News",
@@ -473,3 +514,167 @@ func TestFullMarkWithFieldsNoUrlOrTags(t *testing.T) {
 		t.Fatalf("expected %v, received %v", expected, actual)
 	}
 }
+
+func TestTabulateLinkStatuses(t *testing.T) {
+	statuses := []*marks.LinkStatus{
+		&marks.LinkStatus{
+			Mark:       &marks.Mark{Id: "Google", Url: "https://www.google.com"},
+			StatusCode: 200,
+			Location:   "https://www.google.com",
+		},
+		&marks.LinkStatus{
+			Mark:       &marks.Mark{Id: "Abc News", Url: "http://www.abc.net.au/news/"},
+			StatusCode: 200,
+			Location:   "https://www.abc.net.au/news/",
+		},
+		&marks.LinkStatus{
+			Mark:       &marks.Mark{Id: "Gone", Url: "https://gone.com"},
+			StatusCode: 404,
+		},
+		&marks.LinkStatus{
+			Mark: &marks.Mark{Id: "Broken", Url: "https://broken.com"},
+			Err:  errors.New("no such host"),
+		},
+	}
+	expected := []string{
+		"green[200]    Google      https://www.google.com         ",
+		"green[200]    Abc News    http://www.abc.net.au/news/    -> https://www.abc.net.au/news/",
+		"red[404]      Gone        https://gone.com               ",
+		"red[error]    Broken      https://broken.com             no such host",
+	}
+	p := NewTestPrinter()
+	p.colorizer.(*mocks.Colorizer).RedFn = func(text string) string {
+		return fmt.Sprintf("red[%v]", text)
+	}
+	p.colorizer.(*mocks.Colorizer).GreenFn = func(text string) string {
+		return fmt.Sprintf("green[%v]", text)
+	}
+	actual, err := p.TabulateLinkStatuses(statuses)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected: %q, received: %q", expected, actual)
+	}
+}
+
+func TestTabulateSearches(t *testing.T) {
+	searches := []*marks.Search{
+		&marks.Search{Name: "daily", Query: "tag:standup AND url:jira"},
+		&marks.Search{Name: "uk", Query: "@daily OR tag:uk"},
+	}
+	expected := []string{
+		"colorized[@daily]    tag:standup AND url:jira",
+		"colorized[@uk]       @daily OR tag:uk",
+	}
+	p := NewTestPrinter()
+	p.colorizer.(*mocks.Colorizer).ColorizeFn = func(colorName, text string) (string, error) {
+		return fmt.Sprintf("colorized[%v]", text), nil
+	}
+	actual, err := p.TabulateSearches(searches)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected: %q, received: %q", expected, actual)
+	}
+}
+
+func TestTabulateSessions(t *testing.T) {
+	sessions := []*marks.Session{
+		&marks.Session{Name: "morning", Marks: []string{"Jira", "Calendar", "Slack"}},
+		&marks.Session{Name: "news", Marks: []string{"Abc News"}},
+	}
+	expected := []string{
+		"colorized[morning]    Jira, Calendar, Slack",
+		"colorized[news]       Abc News",
+	}
+	p := NewTestPrinter()
+	p.colorizer.(*mocks.Colorizer).ColorizeFn = func(colorName, text string) (string, error) {
+		return fmt.Sprintf("colorized[%v]", text), nil
+	}
+	actual, err := p.TabulateSessions(sessions)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected: %q, received: %q", expected, actual)
+	}
+}
+
+func TestTabulateBrowsers(t *testing.T) {
+	browsers := []*marks.Browser{
+		&marks.Browser{Name: "brave", Command: "brave-browser", Args: "--profile-directory={{.Profile}} {{.Url}}", Profile: "Work"},
+		&marks.Browser{Name: "default", Command: "xdg-open {{.Url}}"},
+	}
+	expected := []string{
+		"colorized[brave]      brave-browser --profile-directory={{.Profile}} {{.Url}}    Work",
+		"colorized[default]    xdg-open {{.Url}}",
+	}
+	p := NewTestPrinter()
+	p.colorizer.(*mocks.Colorizer).ColorizeFn = func(colorName, text string) (string, error) {
+		return fmt.Sprintf("colorized[%v]", text), nil
+	}
+	actual, err := p.TabulateBrowsers(browsers)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected: %q, received: %q", expected, actual)
+	}
+}
+
+func TestTabulateTagCounts(t *testing.T) {
+	counts := []*marks.TagCount{
+		&marks.TagCount{Tag: "news", Count: 12},
+		&marks.TagCount{Tag: "current affairs", Count: 3},
+	}
+	expected := []string{
+		"colorized[news]               12",
+		"colorized[current affairs]    3",
+	}
+	p := NewTestPrinter()
+	p.colorizer.(*mocks.Colorizer).ColorizeFn = func(colorName, text string) (string, error) {
+		return fmt.Sprintf("colorized[%v]", text), nil
+	}
+	actual, err := p.TabulateTagCounts(counts)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected: %q, received: %q", expected, actual)
+	}
+}
+
+func TestTabulateTagTree(t *testing.T) {
+	nodes := []*marks.TagNode{
+		&marks.TagNode{Name: "news", Path: "news", Count: 1},
+		&marks.TagNode{Name: "work", Path: "work", Count: 3, Children: []*marks.TagNode{
+			&marks.TagNode{Name: "admin", Path: "work/admin", Count: 1, Children: []*marks.TagNode{
+				&marks.TagNode{Name: "hr", Path: "work/admin/hr", Count: 1},
+			}},
+			&marks.TagNode{Name: "projectx", Path: "work/projectx", Count: 2, Children: []*marks.TagNode{
+				&marks.TagNode{Name: "ci", Path: "work/projectx/ci", Count: 1},
+			}},
+		}},
+	}
+	expected := []string{
+		"colorized[news] (1)",
+		"colorized[work] (3)",
+		"├── colorized[admin] (1)",
+		"│   └── colorized[hr] (1)",
+		"└── colorized[projectx] (2)",
+		"    └── colorized[ci] (1)",
+	}
+	p := NewTestPrinter()
+	p.colorizer.(*mocks.Colorizer).ColorizeFn = func(colorName, text string) (string, error) {
+		return fmt.Sprintf("colorized[%v]", text), nil
+	}
+	actual, err := p.TabulateTagTree(nodes)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected: %q, received: %q", expected, actual)
+	}
+}
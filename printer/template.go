@@ -0,0 +1,137 @@
+package printer
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+	"unicode/utf8"
+
+	"github.com/tomguerney/marks/marks"
+)
+
+// templatePrinter renders each recorded mark with a user-supplied
+// text/template, e.g. --format '{{.Id}}\t{{.Url}}'.
+type templatePrinter struct {
+	*formatPrinter
+	tmpl *template.Template
+}
+
+var escapeReplacer = strings.NewReplacer(`\t`, "\t", `\n`, "\n")
+
+func NewTemplatePrinter(config *marks.Config, colorizer colorizer) (*templatePrinter, error) {
+	p := &templatePrinter{formatPrinter: NewFormatPrinter(config, colorizer)}
+	tmpl, err := template.New("format").
+		Funcs(p.funcs()).
+		Parse(escapeReplacer.Replace(config.Format))
+	if err != nil {
+		return nil, err
+	}
+	p.tmpl = tmpl
+	return p, nil
+}
+
+func (p *templatePrinter) Record(action string, mks ...*marks.Mark) error {
+	for _, m := range mks {
+		if err := p.execute(m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RecordTagCounts renders each count, e.g. --format '{{.Tag}}\t{{.Count}}'.
+func (p *templatePrinter) RecordTagCounts(action string, counts []*marks.TagCount) error {
+	for _, count := range counts {
+		if err := p.execute(count); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RecordTagTree renders each node of the tree, parents before children.
+func (p *templatePrinter) RecordTagTree(action string, nodes []*marks.TagNode) error {
+	for _, node := range flattenTagTree(nodes) {
+		if err := p.execute(node); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RecordSearches renders each search, e.g. --format '@{{.Name}}\t{{.Query}}'.
+func (p *templatePrinter) RecordSearches(action string, searches []*marks.Search) error {
+	for _, search := range searches {
+		if err := p.execute(search); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RecordSessions renders each session, e.g. --format '{{.Name}}\t{{.Marks | join ", "}}'.
+func (p *templatePrinter) RecordSessions(action string, sessions []*marks.Session) error {
+	for _, session := range sessions {
+		if err := p.execute(session); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RecordBrowsers renders each browser, e.g. --format '{{.Name}}\t{{.Command}} {{.Args}}'.
+func (p *templatePrinter) RecordBrowsers(action string, browsers []*marks.Browser) error {
+	for _, browser := range browsers {
+		if err := p.execute(browser); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *templatePrinter) execute(data interface{}) error {
+	if err := p.tmpl.Execute(p.out, data); err != nil {
+		return err
+	}
+	fmt.Fprintln(p.out)
+	return nil
+}
+
+func (p *templatePrinter) funcs() template.FuncMap {
+	return template.FuncMap{
+		"join":     join,
+		"color":    p.colorizer.Colorize,
+		"truncate": truncate,
+		"pad":      pad,
+		"padLeft":  padLeft,
+	}
+}
+
+func join(sep string, elems []string) string {
+	return strings.Join(elems, sep)
+}
+
+func truncate(length int, text string) string {
+	if length < 0 || utf8.RuneCountInString(text) <= length {
+		return text
+	}
+	runes := []rune(text)
+	if length <= 3 {
+		return string(runes[:length])
+	}
+	return string(runes[:length-3]) + "..."
+}
+
+func pad(length int, text string) string {
+	if n := length - utf8.RuneCountInString(text); n > 0 {
+		return text + strings.Repeat(" ", n)
+	}
+	return text
+}
+
+func padLeft(length int, text string) string {
+	if n := length - utf8.RuneCountInString(text); n > 0 {
+		return strings.Repeat(" ", n) + text
+	}
+	return text
+}
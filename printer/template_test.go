@@ -0,0 +1,114 @@
+package printer
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/tomguerney/marks/marks"
+	"github.com/tomguerney/marks/mocks"
+)
+
+func newTestTemplatePrinter(format string) (*templatePrinter, *strings.Builder, error) {
+	config := mocks.NewConfig()
+	config.Format = format
+	colorizer := mocks.NewColorizer()
+	colorizer.ColorizeFn = func(colorName, text string) (string, error) {
+		return fmt.Sprintf("%v[%v]", colorName, text), nil
+	}
+	p, err := NewTemplatePrinter(config, colorizer)
+	if err != nil {
+		return nil, nil, err
+	}
+	out := &strings.Builder{}
+	p.out = out
+	return p, out, nil
+}
+
+func TestTemplateRecord(t *testing.T) {
+	p, out, err := newTestTemplatePrinter(`{{.Id}}\t{{.Url}}`)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if err := p.Record("list", mocks.DefaultMarks[0], mocks.DefaultMarks[1]); err != nil {
+		t.Fatal(err.Error())
+	}
+	expected := getMultilineString([]string{
+		"Abc News\thttps://www.abc.net.au/news/",
+		"Google\thttps://www.google.com",
+	})
+	if out.String() != expected {
+		t.Fatalf("expected %v, received %v", expected, out.String())
+	}
+}
+
+func TestTemplateRecordWithFuncs(t *testing.T) {
+	format := `{{.Id | pad 10 | color "green"}}|{{.Url | truncate 12}}|{{.Tags | join "; "}}`
+	p, out, err := newTestTemplatePrinter(format)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if err := p.Record("list", mocks.DefaultMarks[0]); err != nil {
+		t.Fatal(err.Error())
+	}
+	expected := "green[Abc News  ]|https://w...|news; current affairs\n"
+	if out.String() != expected {
+		t.Fatalf("expected %v, received %v", expected, out.String())
+	}
+}
+
+func TestTemplateRecordTagCounts(t *testing.T) {
+	p, out, err := newTestTemplatePrinter(`{{.Tag}}\t{{.Count}}`)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	counts := []*marks.TagCount{&marks.TagCount{Tag: "news", Count: 2}, &marks.TagCount{Tag: "uk", Count: 1}}
+	if err := p.RecordTagCounts("tags list", counts); err != nil {
+		t.Fatal(err.Error())
+	}
+	expected := getMultilineString([]string{"news\t2", "uk\t1"})
+	if out.String() != expected {
+		t.Fatalf("expected %v, received %v", expected, out.String())
+	}
+}
+
+func TestTemplateParseError(t *testing.T) {
+	_, _, err := newTestTemplatePrinter("{{.Id")
+	if err == nil {
+		t.Fatal("should return error")
+	}
+}
+
+func TestTemplateExecuteError(t *testing.T) {
+	p, _, err := newTestTemplatePrinter("{{.NotAField}}")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if err := p.Record("list", mocks.DefaultMarks[0]); err == nil {
+		t.Fatal("should return error")
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	cases := []struct {
+		length   int
+		text     string
+		expected string
+	}{
+		{10, "short", "short"},
+		{8, "longer text", "longe..."},
+		{2, "longer text", "lo"},
+	}
+	for _, c := range cases {
+		if actual := truncate(c.length, c.text); actual != c.expected {
+			t.Fatalf("expected %v, received %v", c.expected, actual)
+		}
+	}
+}
+
+func TestPadLeft(t *testing.T) {
+	expected := "   id"
+	if actual := padLeft(5, "id"); actual != expected {
+		t.Fatalf("expected %v, received %v", expected, actual)
+	}
+}
@@ -1,6 +1,8 @@
 package prompter
 
 import (
+	"fmt"
+
 	"github.com/manifoldco/promptui"
 )
 
@@ -26,6 +28,62 @@ func (p *prompter) Select(label string, table []string) (i int, err error) {
 	return i, nil
 }
 
+// MultiSelect shows the table with a checkbox against each row, toggling a
+// row each time it is chosen, until "Done" is chosen. It returns the indexes
+// of the checked rows in table order.
+func (p *prompter) MultiSelect(label string, table []string) ([]int, error) {
+
+	checked := make([]bool, len(table))
+	cursor, scroll := 0, 0
+
+	for {
+		items := []string{"Done"}
+		for i, row := range table {
+			box := "[ ]"
+			if checked[i] {
+				box = "[x]"
+			}
+			items = append(items, fmt.Sprintf("%v %v", box, row))
+		}
+
+		prompt := promptui.Select{
+			Label:        label,
+			Items:        items,
+			HideSelected: true,
+		}
+
+		i, _, err := prompt.RunCursorAt(cursor, scroll)
+		if err != nil {
+			return nil, err
+		}
+
+		if i == 0 {
+			break
+		}
+
+		checked[i-1] = !checked[i-1]
+		cursor, scroll = i, prompt.ScrollPosition()
+	}
+
+	selected := []int{}
+	for i := range checked {
+		if checked[i] {
+			selected = append(selected, i)
+		}
+	}
+
+	return selected, nil
+}
+
+func (p *prompter) Input(label string) (string, error) {
+
+	prompt := promptui.Prompt{
+		Label: label,
+	}
+
+	return prompt.Run()
+}
+
 func (p *prompter) Confirm(label string) bool {
 
 	prompt := promptui.Prompt{
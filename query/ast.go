@@ -0,0 +1,226 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/tomguerney/marks/fuzzy"
+	"github.com/tomguerney/marks/marks"
+)
+
+// Node is a parsed query that can be matched against marks and rendered
+// back into query syntax.
+type Node interface {
+	Match(m *marks.Mark, env *Env) bool
+	String() string
+}
+
+// Env holds the settings a query is evaluated with.
+type Env struct {
+	// Fuzzy matches id, url and tag terms with the fuzzy package instead of
+	// by substring and equality.
+	Fuzzy bool
+	// Separator splits hierarchical tags, so that a tag term also matches
+	// the tag's descendants. An empty separator matches whole tags only.
+	Separator string
+	// Normalizer, when set, compares tag terms and tags in their canonical
+	// form, so that aliases match the tags they stand for.
+	Normalizer *marks.TagNormalizer
+}
+
+type all struct{}
+
+type and struct {
+	left, right Node
+}
+
+type or struct {
+	left, right Node
+}
+
+type not struct {
+	operand Node
+}
+
+// ref is a reference to a saved search, kept so that String renders the
+// reference rather than its expansion.
+type ref struct {
+	name string
+	node Node
+}
+
+// term matches a single field. Text fields hold their value in value;
+// time fields hold it in time and visits in number.
+type term struct {
+	field  string
+	op     string
+	value  string
+	time   time.Time
+	number int
+}
+
+func (n *all) Match(m *marks.Mark, env *Env) bool {
+	return true
+}
+
+func (n *all) String() string {
+	return ""
+}
+
+func (n *and) Match(m *marks.Mark, env *Env) bool {
+	return n.left.Match(m, env) && n.right.Match(m, env)
+}
+
+func (n *and) String() string {
+	return fmt.Sprintf("%v AND %v", group(n.left), group(n.right))
+}
+
+func (n *or) Match(m *marks.Mark, env *Env) bool {
+	return n.left.Match(m, env) || n.right.Match(m, env)
+}
+
+func (n *or) String() string {
+	return fmt.Sprintf("%v OR %v", group(n.left), group(n.right))
+}
+
+func (n *not) Match(m *marks.Mark, env *Env) bool {
+	return !n.operand.Match(m, env)
+}
+
+func (n *not) String() string {
+	return fmt.Sprintf("NOT %v", group(n.operand))
+}
+
+func (n *ref) Match(m *marks.Mark, env *Env) bool {
+	return n.node.Match(m, env)
+}
+
+func (n *ref) String() string {
+	return fmt.Sprintf("@%v", n.name)
+}
+
+// group parenthesises compound nodes so that String output parses back
+// into the same tree.
+func group(n Node) string {
+	switch n.(type) {
+	case *and, *or:
+		return fmt.Sprintf("(%v)", n)
+	default:
+		return n.String()
+	}
+}
+
+func (n *term) Match(m *marks.Mark, env *Env) bool {
+	switch n.field {
+	case "":
+		return matchText(n.value, m.Id, env) || matchText(n.value, m.Url, env)
+	case "id":
+		return matchText(n.value, m.Id, env)
+	case "url":
+		return matchText(n.value, m.Url, env)
+	case "tag":
+		for _, tag := range m.Tags {
+			if matchTag(n.value, tag, env) {
+				return true
+			}
+		}
+		return false
+	case "notes":
+		return matchText(n.value, m.Notes, env)
+	case "text":
+		for _, text := range append([]string{m.Id, m.Url, m.Description, m.Notes}, m.Tags...) {
+			if matchText(n.value, text, env) {
+				return true
+			}
+		}
+		return false
+	case "created":
+		return compareTime(m.CreatedAt(), n.op, n.time)
+	case "updated":
+		return compareTime(m.UpdatedAt(), n.op, n.time)
+	case "opened":
+		return compareTime(m.LastOpenedAt(), n.op, n.time)
+	case "visits":
+		return compareNumber(m.Visits, n.op, n.number)
+	default:
+		return false
+	}
+}
+
+func (n *term) String() string {
+	value := quote(n.value)
+	if n.field == "" {
+		if strings.HasPrefix(n.value, "@") && value == n.value {
+			return fmt.Sprintf("\"%v\"", n.value)
+		}
+		if i := strings.Index(n.value, ":"); i >= 0 && value == n.value && isField(n.value[:i]) {
+			return fmt.Sprintf("\"%v\"", n.value)
+		}
+		return value
+	}
+	return fmt.Sprintf("%v:%v%v", n.field, n.op, value)
+}
+
+// quote wraps values that would otherwise lex differently in quotes.
+func quote(value string) string {
+	if value != "" && !strings.ContainsAny(value, " \t\n\"()\\") &&
+		value != "AND" && value != "OR" && value != "NOT" {
+		return value
+	}
+	escaped := strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value)
+	return fmt.Sprintf("\"%v\"", escaped)
+}
+
+func matchText(pattern, text string, env *Env) bool {
+	if env.Fuzzy {
+		_, ok := fuzzy.Score(pattern, text)
+		return ok
+	}
+	return strings.Contains(strings.ToLower(text), strings.ToLower(pattern))
+}
+
+func matchTag(pattern, tag string, env *Env) bool {
+	if env.Normalizer != nil {
+		pattern, tag = env.Normalizer.Normalize(pattern), env.Normalizer.Normalize(tag)
+	}
+	if env.Fuzzy {
+		_, ok := fuzzy.ScoreTag(pattern, tag, env.Separator)
+		return ok
+	}
+	return marks.TagMatches(pattern, tag, env.Separator)
+}
+
+// compareTime never matches marks that have no time recorded.
+func compareTime(t time.Time, op string, bound time.Time) bool {
+	if t.IsZero() {
+		return false
+	}
+	switch op {
+	case ">":
+		return t.After(bound)
+	case ">=":
+		return !t.Before(bound)
+	case "<":
+		return t.Before(bound)
+	case "<=":
+		return !t.After(bound)
+	default:
+		return false
+	}
+}
+
+func compareNumber(n int, op string, bound int) bool {
+	switch op {
+	case ">":
+		return n > bound
+	case ">=":
+		return n >= bound
+	case "<":
+		return n < bound
+	case "<=":
+		return n <= bound
+	default:
+		return n == bound
+	}
+}
@@ -0,0 +1,120 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/tomguerney/marks/marks"
+)
+
+var testMark = &marks.Mark{
+	Id:         "Abc News",
+	Url:        "https://www.abc.net.au/news/",
+	Tags:       []string{"news", "au", "current affairs"},
+	Created:    marks.Timestamp(testNow.AddDate(0, -1, 0)),
+	LastOpened: marks.Timestamp(testNow.AddDate(0, 0, -1)),
+	Visits:     4,
+	Notes:      "Saved for the election coverage\nCheck the live blog",
+}
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		expr     string
+		expected bool
+	}{
+		{"", true},
+		{"abc", true},
+		{"net.au", true},
+		{"id:news", true},
+		{"id:bbc", false},
+		{"tag:NEWS", true},
+		{"tag:new", false},
+		{"tag:\"current affairs\"", true},
+		{"tag:news AND (tag:au OR tag:uk) AND NOT url:paywall AND created:>2025-01", true},
+		{"tag:news AND tag:uk", false},
+		{"NOT tag:news", false},
+		{"created:>30d", false},
+		{"created:<=30d", true},
+		{"opened:>2d", true},
+		{"updated:<2030", false},
+		{"visits:4", true},
+		{"visits:>4", false},
+		{"notes:ELECTION", true},
+		{"notes:abc", false},
+		{"text:election", true},
+		{"text:affairs", true},
+		{"text:abc.net", true},
+		{"text:paywall", false},
+		{"election", false},
+	}
+	for _, test := range tests {
+		node, err := Parse(test.expr, testNow, nil)
+		if err != nil {
+			t.Fatalf("%v: %v", test.expr, err.Error())
+		}
+		if actual := node.Match(testMark, &Env{}); actual != test.expected {
+			t.Fatalf("%v: expected %v, received %v", test.expr, test.expected, actual)
+		}
+	}
+}
+
+func TestMatchFuzzy(t *testing.T) {
+	node, err := Parse("id:abcnews tag:\"curent affairs\"", testNow, nil)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if node.Match(testMark, &Env{}) {
+		t.Fatal("should not match without fuzzy")
+	}
+	if !node.Match(testMark, &Env{Fuzzy: true}) {
+		t.Fatal("should match with fuzzy")
+	}
+}
+
+func TestMatchHierarchicalTags(t *testing.T) {
+	m := &marks.Mark{Id: "ci", Tags: []string{"work/projectx/ci"}}
+	tests := []struct {
+		expr      string
+		separator string
+		expected  bool
+	}{
+		{"tag:work", "/", true},
+		{"tag:Work/ProjectX", "/", true},
+		{"tag:work/projectx/ci", "/", true},
+		{"tag:work/proj", "/", false},
+		{"tag:work", "", false},
+		{"tag:work/projectx/ci", "", true},
+	}
+	for _, test := range tests {
+		node, err := Parse(test.expr, testNow, nil)
+		if err != nil {
+			t.Fatalf("%v: %v", test.expr, err.Error())
+		}
+		if actual := node.Match(m, &Env{Separator: test.separator}); actual != test.expected {
+			t.Fatalf("%v %q: expected %v, received %v", test.expr, test.separator, test.expected, actual)
+		}
+	}
+}
+
+func TestMatchNormalizedTags(t *testing.T) {
+	m := &marks.Mark{Id: "k8s docs", Tags: []string{"Kubernetes", "current affairs"}}
+	normalizer := marks.NewTagNormalizer(&marks.Config{
+		AppConfig: &marks.AppConfig{},
+		UserConfig: &marks.UserConfig{
+			NormalizeTags:    true,
+			TagWordSeparator: "-",
+			TagAliases:       map[string]string{"k8s": "kubernetes"},
+		},
+	})
+	for _, expr := range []string{"tag:k8s", "tag:current-affairs", "tag:Current_Affairs"} {
+		node, err := Parse(expr, testNow, nil)
+		if err != nil {
+			t.Fatalf("%v: %v", expr, err.Error())
+		}
+		if node.Match(m, &Env{}) {
+			t.Fatalf("%v: should not match without normalizing", expr)
+		}
+		if !node.Match(m, &Env{Normalizer: normalizer}) {
+			t.Fatalf("%v: should match when normalizing", expr)
+		}
+	}
+}
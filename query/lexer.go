@@ -0,0 +1,136 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenLParen
+	tokenRParen
+	tokenAnd
+	tokenOr
+	tokenNot
+	tokenTerm
+)
+
+func (k tokenKind) String() string {
+	switch k {
+	case tokenEOF:
+		return "end of query"
+	case tokenLParen:
+		return "\"(\""
+	case tokenRParen:
+		return "\")\""
+	case tokenAnd:
+		return "AND"
+	case tokenOr:
+		return "OR"
+	case tokenNot:
+		return "NOT"
+	default:
+		return "term"
+	}
+}
+
+type token struct {
+	kind tokenKind
+	// text is the term with any quotes removed.
+	text string
+	// quoted reports whether any part of the term was quoted, which stops
+	// a quoted "AND" from being read as a keyword.
+	quoted bool
+	// colon is the byte offset in text of a colon that ends an unquoted
+	// field name, or -1.
+	colon int
+	pos   int
+}
+
+// SyntaxError describes a malformed query and where in it the problem is.
+type SyntaxError struct {
+	Pos int
+	Msg string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("query: %v at position %v", e.Msg, e.Pos+1)
+}
+
+// lex splits the query into tokens. Terms run until whitespace or a
+// parenthesis; double quotes group spaces and parentheses into a term and
+// a backslash escapes the character after it.
+func lex(input string) ([]token, error) {
+	runes := []rune(input)
+	tokens := []token{}
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, token{kind: tokenLParen, text: "(", colon: -1, pos: i})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{kind: tokenRParen, text: ")", colon: -1, pos: i})
+			i++
+		default:
+			t, next, err := lexTerm(runes, i)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, t)
+			i = next
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, colon: -1, pos: len(runes)}), nil
+}
+
+func lexTerm(runes []rune, start int) (token, int, error) {
+	builder := strings.Builder{}
+	quoted := false
+	colon := -1
+	i := start
+	for i < len(runes) {
+		r := runes[i]
+		if unicode.IsSpace(r) || r == '(' || r == ')' {
+			break
+		}
+		if r != '"' {
+			if r == ':' && !quoted && colon < 0 {
+				colon = builder.Len()
+			}
+			builder.WriteRune(r)
+			i++
+			continue
+		}
+		quoted = true
+		open := i
+		i++
+		for ; i < len(runes) && runes[i] != '"'; i++ {
+			if runes[i] == '\\' && i+1 < len(runes) {
+				i++
+			}
+			builder.WriteRune(runes[i])
+		}
+		if i == len(runes) {
+			return token{}, 0, &SyntaxError{open, "unterminated quote"}
+		}
+		i++
+	}
+	t := token{kind: tokenTerm, text: builder.String(), quoted: quoted, colon: colon, pos: start}
+	if !quoted {
+		switch t.text {
+		case "AND":
+			t.kind = tokenAnd
+		case "OR":
+			t.kind = tokenOr
+		case "NOT":
+			t.kind = tokenNot
+		}
+	}
+	return t, i, nil
+}
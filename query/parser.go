@@ -0,0 +1,276 @@
+// Package query parses and evaluates search expressions such as
+//
+//	tag:news AND (tag:au OR tag:uk) AND NOT url:paywall AND created:>2025-01
+//
+// Terms are field:value pairs, bare words, which match ids and urls, or
+// @name references to saved searches. Adjacent terms are joined with AND.
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tomguerney/marks/arg"
+)
+
+var textFields = map[string]bool{"id": true, "url": true, "tag": true, "notes": true, "text": true}
+
+var timeFields = map[string]bool{"created": true, "updated": true, "opened": true}
+
+func isField(name string) bool {
+	name = strings.ToLower(name)
+	return textFields[name] || timeFields[name] || name == "visits"
+}
+
+// operators are checked in order so that ">=" is not read as ">".
+var operators = []string{">=", "<=", ">", "<", "="}
+
+// Resolver returns the expression saved under name, for @name references.
+type Resolver func(name string) (string, error)
+
+type parser struct {
+	tokens  []token
+	pos     int
+	now     time.Time
+	resolve Resolver
+	// resolving holds the saved searches being expanded, to catch cycles.
+	resolving []string
+}
+
+// Parse parses expr into a Node. Relative times such as created:>30d are
+// resolved against now and @name references with resolve, which may be
+// nil if references are not allowed. An empty expression matches every
+// mark.
+func Parse(expr string, now time.Time, resolve Resolver) (Node, error) {
+	return parse(expr, now, resolve, nil)
+}
+
+func parse(expr string, now time.Time, resolve Resolver, resolving []string) (Node, error) {
+	tokens, err := lex(expr)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens, now: now, resolve: resolve, resolving: resolving}
+	if p.peek().kind == tokenEOF {
+		return &all{}, nil
+	}
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, &SyntaxError{t.pos, fmt.Sprintf("unexpected %v", describe(t))}
+	}
+	return node, nil
+}
+
+// FromFilter builds the query equivalent to filtering by a partial id, a
+// partial url and every one of tags.
+func FromFilter(id, url string, tags []string) Node {
+	terms := []Node{}
+	if id != "" {
+		terms = append(terms, &term{field: "id", value: id})
+	}
+	if url != "" {
+		terms = append(terms, &term{field: "url", value: url})
+	}
+	for _, tag := range tags {
+		terms = append(terms, &term{field: "tag", value: tag})
+	}
+	if len(terms) == 0 {
+		return &all{}
+	}
+	node := terms[0]
+	for _, t := range terms[1:] {
+		node = &and{node, t}
+	}
+	return node
+}
+
+// Combine joins expr with the query built by FromFilter, so that both
+// must match.
+func Combine(expr, id, url string, tags []string) string {
+	filter := FromFilter(id, url, tags).String()
+	switch {
+	case filter == "":
+		return expr
+	case strings.TrimSpace(expr) == "":
+		return filter
+	default:
+		return fmt.Sprintf("(%v) AND %v", expr, filter)
+	}
+}
+
+// WithText joins expr with a text term for text, so that both must match.
+func WithText(expr, text string) string {
+	if strings.TrimSpace(text) == "" {
+		return expr
+	}
+	t := (&term{field: "text", value: text}).String()
+	if strings.TrimSpace(expr) == "" {
+		return t
+	}
+	return fmt.Sprintf("(%v) AND %v", expr, t)
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) parseOr() (Node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokenOr {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &or{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (Node, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		switch p.peek().kind {
+		case tokenAnd:
+			p.next()
+		case tokenTerm, tokenNot, tokenLParen:
+		default:
+			return left, nil
+		}
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &and{left, right}
+	}
+}
+
+func (p *parser) parseNot() (Node, error) {
+	if p.peek().kind == tokenNot {
+		p.next()
+		operand, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &not{operand}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (Node, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenLParen:
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokenRParen {
+			return nil, &SyntaxError{closing.pos, fmt.Sprintf("expected \")\" to close \"(\" at position %v, found %v", t.pos+1, describe(closing))}
+		}
+		return node, nil
+	case tokenTerm:
+		return p.parseTerm(t)
+	default:
+		return nil, &SyntaxError{t.pos, fmt.Sprintf("expected a term, found %v", describe(t))}
+	}
+}
+
+func (p *parser) parseTerm(t token) (Node, error) {
+	if !t.quoted && strings.HasPrefix(t.text, "@") {
+		return p.parseRef(t)
+	}
+	if t.colon < 0 {
+		return &term{value: t.text}, nil
+	}
+	field := strings.ToLower(t.text[:t.colon])
+	value := t.text[t.colon+1:]
+	switch {
+	case textFields[field]:
+		if value == "" {
+			return nil, &SyntaxError{t.pos, fmt.Sprintf("%v needs a value", field)}
+		}
+		return &term{field: field, value: value}, nil
+	case timeFields[field]:
+		op, value := splitOperator(value)
+		if op == "" || op == "=" {
+			return nil, &SyntaxError{t.pos, fmt.Sprintf("%v needs a comparison such as %v:>2025-01", field, field)}
+		}
+		bound, err := arg.ParseTime(value, p.now)
+		if err != nil {
+			return nil, &SyntaxError{t.pos, err.Error()}
+		}
+		return &term{field: field, op: op, value: value, time: bound}, nil
+	case field == "visits":
+		op, value := splitOperator(value)
+		number, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, &SyntaxError{t.pos, fmt.Sprintf("visits needs a number, found \"%v\"", value)}
+		}
+		return &term{field: field, op: op, value: value, number: number}, nil
+	default:
+		return &term{value: t.text}, nil
+	}
+}
+
+func (p *parser) parseRef(t token) (Node, error) {
+	name := t.text[1:]
+	if p.resolve == nil {
+		return nil, &SyntaxError{t.pos, "saved searches cannot be used here"}
+	}
+	for _, resolving := range p.resolving {
+		if strings.EqualFold(resolving, name) {
+			return nil, &SyntaxError{t.pos, fmt.Sprintf("saved search @%v refers to itself", name)}
+		}
+	}
+	expr, err := p.resolve(name)
+	if err != nil {
+		return nil, &SyntaxError{t.pos, err.Error()}
+	}
+	node, err := parse(expr, p.now, p.resolve, append(p.resolving, name))
+	if err, ok := err.(*SyntaxError); ok {
+		if strings.HasPrefix(err.Msg, "saved search") {
+			return nil, &SyntaxError{t.pos, err.Msg}
+		}
+		return nil, &SyntaxError{t.pos, fmt.Sprintf("in saved search @%v, position %v: %v", name, err.Pos+1, err.Msg)}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &ref{name, node}, nil
+}
+
+func splitOperator(value string) (string, string) {
+	for _, op := range operators {
+		if strings.HasPrefix(value, op) {
+			return op, value[len(op):]
+		}
+	}
+	return "", value
+}
+
+func describe(t token) string {
+	if t.kind == tokenTerm {
+		return fmt.Sprintf("\"%v\"", t.text)
+	}
+	return t.kind.String()
+}
@@ -0,0 +1,154 @@
+package query
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+var testNow = time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+func TestParseString(t *testing.T) {
+	tests := []struct {
+		expr     string
+		expected string
+	}{
+		{"", ""},
+		{"news", "news"},
+		{"tag:news tag:uk", "tag:news AND tag:uk"},
+		{"tag:news AND (tag:au OR tag:uk)", "tag:news AND (tag:au OR tag:uk)"},
+		{"a OR b AND c", "a OR (b AND c)"},
+		{"NOT url:paywall", "NOT url:paywall"},
+		{"NOT NOT a", "NOT NOT a"},
+		{"tag:\"current affairs\"", "tag:\"current affairs\""},
+		{"\"AND\"", "\"AND\""},
+		{"\"id:literal\"", "\"id:literal\""},
+		{"created:>2025-01 visits:>=3", "created:>2025-01 AND visits:>=3"},
+		{"https://www.abc.net.au", "https://www.abc.net.au"},
+		{"\"@literal\"", "\"@literal\""},
+	}
+	for _, test := range tests {
+		node, err := Parse(test.expr, testNow, nil)
+		if err != nil {
+			t.Fatalf("%v: %v", test.expr, err.Error())
+		}
+		if actual := node.String(); actual != test.expected {
+			t.Fatalf("%v: expected %v, received %v", test.expr, test.expected, actual)
+		}
+		reparsed, err := Parse(node.String(), testNow, nil)
+		if err != nil {
+			t.Fatalf("%v: %v", node.String(), err.Error())
+		}
+		if reparsed.String() != node.String() {
+			t.Fatalf("expected %v, received %v", node.String(), reparsed.String())
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		expr     string
+		expected string
+	}{
+		{"tag:news AND", "query: expected a term, found end of query at position 13"},
+		{"(tag:news", "query: expected \")\" to close \"(\" at position 1, found end of query at position 10"},
+		{"tag:news)", "query: unexpected \")\" at position 9"},
+		{"tag:\"news", "query: unterminated quote at position 5"},
+		{"a OR OR b", "query: expected a term, found OR at position 6"},
+		{"created:2025", "query: created needs a comparison such as created:>2025-01 at position 1"},
+		{"x created:>soon", "query: cannot parse \"soon\" as a date or age, e.g. 2025-01-31 or 30d at position 3"},
+		{"visits:>many", "query: visits needs a number, found \"many\" at position 1"},
+		{"tag:", "query: tag needs a value at position 1"},
+	}
+	for _, test := range tests {
+		_, err := Parse(test.expr, testNow, nil)
+		if err == nil {
+			t.Fatalf("%v: expected error", test.expr)
+		}
+		if err.Error() != test.expected {
+			t.Fatalf("%v: expected %v, received %v", test.expr, test.expected, err.Error())
+		}
+	}
+}
+
+func TestCombine(t *testing.T) {
+	tests := []struct {
+		expr     string
+		id       string
+		tags     []string
+		expected string
+	}{
+		{"tag:news", "", nil, "tag:news"},
+		{"", "abc", []string{"current affairs"}, "id:abc AND tag:\"current affairs\""},
+		{"a OR b", "abc", nil, "(a OR b) AND id:abc"},
+	}
+	for _, test := range tests {
+		if actual := Combine(test.expr, test.id, "", test.tags); actual != test.expected {
+			t.Fatalf("expected %v, received %v", test.expected, actual)
+		}
+	}
+}
+
+func TestWithText(t *testing.T) {
+	tests := []struct {
+		expr     string
+		text     string
+		expected string
+	}{
+		{"tag:news", "", "tag:news"},
+		{"", "election", "text:election"},
+		{"a OR b", "live blog", "(a OR b) AND text:\"live blog\""},
+	}
+	for _, test := range tests {
+		if actual := WithText(test.expr, test.text); actual != test.expected {
+			t.Fatalf("expected %v, received %v", test.expected, actual)
+		}
+	}
+}
+
+func testResolver(saved map[string]string) Resolver {
+	return func(name string) (string, error) {
+		if expr, ok := saved[name]; ok {
+			return expr, nil
+		}
+		return "", errors.New(fmt.Sprintf("no saved search named \"%v\"", name))
+	}
+}
+
+func TestParseRef(t *testing.T) {
+	resolve := testResolver(map[string]string{"daily": "tag:standup url:jira", "news": "tag:news OR @daily"})
+	node, err := Parse("@news NOT tag:uk", testNow, resolve)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if node.String() != "@news AND NOT tag:uk" {
+		t.Fatalf("expected @news AND NOT tag:uk, received %v", node.String())
+	}
+	if !node.Match(testMark, &Env{}) {
+		t.Fatal("expected match through saved search")
+	}
+}
+
+func TestParseRefErrors(t *testing.T) {
+	resolve := testResolver(map[string]string{"loop": "tag:a OR @loop", "broken": "tag:a AND"})
+	tests := []struct {
+		expr     string
+		resolve  Resolver
+		expected string
+	}{
+		{"@daily", nil, "query: saved searches cannot be used here at position 1"},
+		{"x @missing", resolve, "query: no saved search named \"missing\" at position 3"},
+		{"@loop", resolve, "query: saved search @loop refers to itself at position 1"},
+		{"@broken", resolve, "query: in saved search @broken, position 10: expected a term, found end of query at position 1"},
+	}
+	for _, test := range tests {
+		_, err := Parse(test.expr, testNow, test.resolve)
+		if err == nil {
+			t.Fatalf("%v: expected error", test.expr)
+		}
+		if err.Error() != test.expected {
+			t.Fatalf("%v: expected %v, received %v", test.expr, test.expected, err.Error())
+		}
+	}
+}
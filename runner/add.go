@@ -1,20 +1,33 @@
 package runner
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/tomguerney/marks/marks"
 )
 
+// maxSuggestedTags bounds how many of a page's keywords are offered as tags.
+const maxSuggestedTags = 5
+
 type add struct {
 	args         *AddArgs
 	config       *marks.Config
 	marksService marks.MarkService
 	printer      marks.Printer
+	prompter     marks.Prompter
+	fetcher      marks.Fetcher
 }
 
 type AddArgs struct {
-	id   string
-	url  string
-	tags []string
+	id      string
+	url     string
+	tags    []string
+	note    string
+	browser string
+	strict  bool
+	fetch   bool
+	slug    bool
 }
 
 func NewAddRunner(
@@ -22,37 +35,98 @@ func NewAddRunner(
 	config *marks.Config,
 	marks marks.MarkService,
 	printer marks.Printer,
+	prompter marks.Prompter,
+	fetcher marks.Fetcher,
 ) *add {
 	return &add{
 		args,
 		config,
 		marks,
 		printer,
+		prompter,
+		fetcher,
 	}
 }
 
-func NewAddArgs(id, url string, tags []string) *AddArgs {
-	return &AddArgs{id, url, tags}
+func NewAddArgs(id, url string, tags []string, note, browser string, strict, fetch, slug bool) *AddArgs {
+	return &AddArgs{id, url, tags, note, browser, strict, fetch, slug}
 }
 
 func (a *add) Run() error {
 
-	exists, err := a.marksService.Contains(a.args.id)
+	mark := &marks.Mark{
+		Id:      a.args.id,
+		Url:     a.args.url,
+		Tags:    a.args.tags,
+		Notes:   a.args.note,
+		Browser: strings.ToLower(a.args.browser),
+	}
+
+	if err := validateBrowser(mark.Browser, a.config.Browsers); err != nil {
+		a.printer.Error("Cannot add bookmark: %v", err.Error())
+		return nil
+	}
+
+	if marks.IsUrlTemplate(mark.Url) {
+		if err := marks.ValidateUrlTemplate(mark.Url); err != nil {
+			a.printer.Error("Url template is not valid: %v", err.Error())
+			return nil
+		}
+	}
+
+	// A given id is checked before the page is fetched, so that a clash costs
+	// no request. An id taken from the page title is checked once fetched.
+	if mark.Id != "" {
+		if taken, err := a.taken(mark.Id); taken || err != nil {
+			return err
+		}
+	}
+
+	// The page's canonical url is only used to find duplicates. The url
+	// given is the one saved.
+	urls := []string{mark.Url}
+
+	if a.args.fetch {
+		page, ok, err := a.fetch(mark)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return nil
+		}
+		if page != nil {
+			urls = append(urls, page.CanonicalUrl)
+		}
+	}
+
+	if mark.Id == "" {
+		a.printer.Error("A bookmark id is required unless it can be fetched from the page title")
+		return nil
+	}
+
+	if a.args.id == "" {
+		if taken, err := a.taken(mark.Id); taken || err != nil {
+			return err
+		}
+	}
+
+	duplicate, err := a.duplicate(urls)
 	if err != nil {
 		return err
 	}
 
-	if exists {
-		a.printer.Error("Bookmark with id \"%v\" already exists", a.args.id)
+	if duplicate != nil && a.args.strict {
+		a.printer.Error("Bookmark \"%v\" already points to %v", duplicate.Id, duplicate.Url)
 		return nil
 	}
 
-	mark := &marks.Mark{
-		Id:   a.args.id,
-		Url:  a.args.url,
-		Tags: a.args.tags,
+	if duplicate != nil {
+		a.printer.Msg("Warning: bookmark \"%v\" already points to %v", duplicate.Id, duplicate.Url)
 	}
 
+	mark.Tags = marks.NewTagNormalizer(a.config).NormalizeAll(mark.Tags)
+	mark.Created = marks.Timestamp(now())
+
 	err = a.marksService.Create(mark)
 	if err != nil {
 		return err
@@ -65,5 +139,114 @@ func (a *add) Run() error {
 
 	a.printer.Msg("Bookmark created: %v", fullMark)
 
-	return nil
+	return a.printer.Record("add", mark)
+}
+
+// taken reports whether a bookmark already has the id, printing an error if
+// so.
+func (a *add) taken(id string) (bool, error) {
+	exists, err := a.marksService.Contains(id)
+	if err != nil {
+		return false, err
+	}
+	if exists {
+		a.printer.Error("Bookmark with id \"%v\" already exists", id)
+	}
+	return exists, nil
+}
+
+// fetch fills in the mark's id, description and tags from its page. It
+// returns false if the mark cannot be added because the page could not be
+// fetched and no id was given.
+func (a *add) fetch(mark *marks.Mark) (*marks.Page, bool, error) {
+
+	page, err := a.fetcher.Fetch(mark.Url)
+	if err != nil && mark.Id == "" {
+		a.printer.Error("Could not fetch %v: %v", mark.Url, err.Error())
+		return nil, false, nil
+	}
+	if err != nil {
+		a.printer.Msg("Warning: could not fetch %v: %v", mark.Url, err.Error())
+		return nil, true, nil
+	}
+
+	if mark.Id == "" {
+		id, err := a.proposeId(page.Title)
+		if err != nil {
+			return nil, false, err
+		}
+		mark.Id = id
+	}
+
+	mark.Description = page.Description
+
+	suggested := a.suggestTags(mark, page.Keywords)
+	if len(suggested) > 0 && a.prompter.Confirm("Add suggested tags "+strings.Join(suggested, ", ")) {
+		mark.Tags = append(mark.Tags, suggested...)
+	}
+
+	return page, true, nil
+}
+
+// proposeId offers the page title, or a slug of it, as the id and asks for
+// another if it is declined.
+func (a *add) proposeId(title string) (string, error) {
+	proposed := title
+	if a.args.slug {
+		proposed = marks.Slug(title)
+	}
+	if proposed == "" {
+		return "", nil
+	}
+	if a.prompter.Confirm(fmt.Sprintf("Use \"%v\" as the id", proposed)) {
+		return proposed, nil
+	}
+	id, err := a.prompter.Input("Id")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(id), nil
+}
+
+func (a *add) suggestTags(mark *marks.Mark, keywords []string) []string {
+	normalizer := marks.NewTagNormalizer(a.config)
+	existing := normalizer.NormalizeAll(mark.Tags)
+	suggested := []string{}
+	for _, keyword := range normalizer.NormalizeAll(keywords) {
+		if len(suggested) == maxSuggestedTags {
+			break
+		}
+		found := false
+		for _, tag := range existing {
+			found = found || strings.EqualFold(tag, keyword)
+		}
+		if !found {
+			suggested = append(suggested, keyword)
+		}
+	}
+	return suggested
+}
+
+// duplicate returns an existing mark whose url has the same canonical form as
+// any of urls, if there is one.
+func (a *add) duplicate(urls []string) (*marks.Mark, error) {
+	canonical := map[string]bool{}
+	for _, u := range urls {
+		if c := marks.CanonicalUrl(u); c != "" {
+			canonical[c] = true
+		}
+	}
+	if len(canonical) == 0 {
+		return nil, nil
+	}
+	mks, err := a.marksService.Marks()
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range mks {
+		if canonical[marks.CanonicalUrl(m.Url)] {
+			return m, nil
+		}
+	}
+	return nil, nil
 }
@@ -2,6 +2,7 @@ package runner
 
 import (
 	"errors"
+	"reflect"
 	"testing"
 
 	"github.com/tomguerney/marks/marks"
@@ -11,10 +12,12 @@ import (
 
 func newTestAddRunner() *add {
 	return &add{
-		args:         &AddArgs{},
+		args:         &AddArgs{id: "id"},
 		config:       mocks.NewConfig(),
 		marksService: mocks.NewMarkService(),
 		printer:      mocks.NewPrinter(),
+		prompter:     mocks.NewPrompter(),
+		fetcher:      mocks.NewFetcher(),
 	}
 }
 
@@ -28,8 +31,14 @@ func TestAddMark(t *testing.T) {
 		}
 	}
 	a.printer.(*mocks.Printer).MsgFn = msgFn
+	a.marksService.(*mocks.MarkService).CreateFn = func(actual *marks.Mark) error {
+		if !actual.Created.Equal(testNow) {
+			t.Fatalf("expected %v, received %v", testNow, actual.Created)
+		}
+		return nil
+	}
 	a.args.id = mark.Id
-	a.args.url = mark.Url
+	a.args.url = "https://www.example.com"
 	a.args.tags = mark.Tags
 	err := a.Run()
 	if err != nil {
@@ -43,6 +52,102 @@ func TestAddMark(t *testing.T) {
 	}
 }
 
+func TestAddMarkNormalizesTags(t *testing.T) {
+	a := newTestAddRunner()
+	a.config.NormalizeTags = true
+	a.config.TagWordSeparator = "-"
+	a.config.TagAliases = map[string]string{"k8s": "kubernetes"}
+	a.marksService.(*mocks.MarkService).CreateFn = func(actual *marks.Mark) error {
+		expected := []string{"kubernetes", "current-affairs"}
+		if !reflect.DeepEqual(actual.Tags, expected) {
+			t.Fatalf("expected %v, received %v", expected, actual.Tags)
+		}
+		return nil
+	}
+	a.args.id = "k8s docs"
+	a.args.tags = []string{"K8s", " Current Affairs", "kubernetes"}
+	if err := a.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if !a.marksService.(*mocks.MarkService).CreateFnCalled {
+		t.Fatal("create should be called")
+	}
+}
+
+func TestAddMarkWarnsOfDuplicateUrl(t *testing.T) {
+	a := newTestAddRunner()
+	var msgs []string
+	a.printer.(*mocks.Printer).MsgFn = func(msg string, i ...interface{}) {
+		msgs = append(msgs, msg)
+	}
+	a.args.id = "abc"
+	a.args.url = "http://abc.net.au/news?utm_source=rss"
+	if err := a.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(msgs) == 0 || msgs[0] != "Warning: bookmark \"%v\" already points to %v" {
+		t.Fatalf("expected warning, received %v", msgs)
+	}
+	if !a.marksService.(*mocks.MarkService).CreateFnCalled {
+		t.Fatal("create should be called")
+	}
+}
+
+func TestAddMarkStrictRefusesDuplicateUrl(t *testing.T) {
+	a := newTestAddRunner()
+	a.args.id = "abc"
+	a.args.url = "http://abc.net.au/news?utm_source=rss"
+	a.args.strict = true
+	if err := a.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if !a.printer.(*mocks.Printer).ErrorFnCalled ||
+		a.marksService.(*mocks.MarkService).CreateFnCalled {
+		t.Fatal("error should be printed without creating")
+	}
+}
+
+func TestAddMarkWithNote(t *testing.T) {
+	a := newTestAddRunner()
+	a.args.note = "Saved for the election coverage"
+	a.marksService.(*mocks.MarkService).CreateFn = func(actual *marks.Mark) error {
+		if actual.Notes != "Saved for the election coverage" {
+			t.Fatalf("unexpected notes %q", actual.Notes)
+		}
+		return nil
+	}
+	if err := a.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if !a.marksService.(*mocks.MarkService).CreateFnCalled {
+		t.Fatal("create should be called")
+	}
+}
+
+func TestAddMarkWithoutId(t *testing.T) {
+	a := newTestAddRunner()
+	a.args.id = ""
+	if err := a.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if !a.printer.(*mocks.Printer).ErrorFnCalled ||
+		a.marksService.(*mocks.MarkService).CreateFnCalled {
+		t.Fatal("error should be printed without creating")
+	}
+}
+
+func TestAddMarkWithInvalidUrlTemplate(t *testing.T) {
+	a := newTestAddRunner()
+	a.args.url = "https://jira.example.com/browse/{{.Args 0"
+	if err := a.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if !a.printer.(*mocks.Printer).ErrorFnCalled ||
+		a.marksService.(*mocks.MarkService).CreateFnCalled {
+		t.Fatal("error should be printed without creating")
+	}
+}
+
 func TestCreateWhenMarkExists(t *testing.T) {
 	a := newTestAddRunner()
 	msgFn := func(actual string, i ...interface{}) {
@@ -67,6 +172,40 @@ func TestCreateWhenMarkExists(t *testing.T) {
 	}
 }
 
+func TestAddMarkChecksIdBeforeFetching(t *testing.T) {
+	a := newTestAddRunner()
+	a.args.url = "https://example.com"
+	a.args.fetch = true
+	a.marksService.(*mocks.MarkService).ContainsFn = func(id string) (bool, error) {
+		return true, nil
+	}
+	if err := a.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if a.fetcher.(*mocks.Fetcher).FetchFnCalled ||
+		a.marksService.(*mocks.MarkService).CreateFnCalled {
+		t.Fatal("fetch and create should not be called")
+	}
+}
+
+func TestAddMarkChecksFetchedId(t *testing.T) {
+	a := newTestAddRunner()
+	a.args.id = ""
+	a.args.url = "https://example.com"
+	a.args.fetch = true
+	var checked string
+	a.marksService.(*mocks.MarkService).ContainsFn = func(id string) (bool, error) {
+		checked = id
+		return true, nil
+	}
+	if err := a.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if checked != "Example Domain" || a.marksService.(*mocks.MarkService).CreateFnCalled {
+		t.Fatalf("the fetched id should be checked, checked %q", checked)
+	}
+}
+
 func TestCreateWhenMarkExistsWithContainsError(t *testing.T) {
 	a := newTestAddRunner()
 	containsFn := func(id string) (bool, error) {
@@ -114,3 +253,151 @@ func TestAddMarkWithFullMarkError(t *testing.T) {
 		t.Fatal("msg should not be called")
 	}
 }
+
+func TestAddMarkFetchesPage(t *testing.T) {
+	a := newTestAddRunner()
+	a.args.id = ""
+	a.args.url = "https://example.com"
+	a.args.tags = []string{"News"}
+	a.args.fetch = true
+	var confirmed string
+	a.prompter.(*mocks.Prompter).ConfirmFn = func(label string) bool {
+		confirmed = label
+		return true
+	}
+	a.marksService.(*mocks.MarkService).CreateFn = func(actual *marks.Mark) error {
+		expected := &marks.Mark{
+			Id:          "Example Domain",
+			Url:         "https://example.com",
+			Tags:        []string{"News", "example"},
+			Created:     marks.Timestamp(testNow),
+			Description: "An example page",
+		}
+		if !reflect.DeepEqual(actual, expected) {
+			t.Fatalf("expected %v, received %v", expected, actual)
+		}
+		return nil
+	}
+	if err := a.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if confirmed != "Add suggested tags example" {
+		t.Fatalf("unexpected confirm label %v", confirmed)
+	}
+	if !a.marksService.(*mocks.MarkService).CreateFnCalled {
+		t.Fatal("create should be called")
+	}
+}
+
+func TestAddMarkFetchesSlug(t *testing.T) {
+	a := newTestAddRunner()
+	a.args.id = ""
+	a.args.url = "https://example.com"
+	a.args.fetch = true
+	a.args.slug = true
+	a.prompter.(*mocks.Prompter).ConfirmFn = func(label string) bool {
+		return label == "Use \"example-domain\" as the id"
+	}
+	a.marksService.(*mocks.MarkService).CreateFn = func(actual *marks.Mark) error {
+		if actual.Id != "example-domain" || len(actual.Tags) != 0 {
+			t.Fatalf("unexpected mark %v", actual)
+		}
+		return nil
+	}
+	if err := a.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+}
+
+func TestAddMarkFetchedIdDeclined(t *testing.T) {
+	a := newTestAddRunner()
+	a.args.id = ""
+	a.args.url = "https://example.com"
+	a.args.fetch = true
+	a.prompter.(*mocks.Prompter).ConfirmFn = func(label string) bool {
+		return label != "Use \"Example Domain\" as the id"
+	}
+	a.prompter.(*mocks.Prompter).InputFn = func(label string) (string, error) {
+		return " example ", nil
+	}
+	a.marksService.(*mocks.MarkService).CreateFn = func(actual *marks.Mark) error {
+		if actual.Id != "example" {
+			t.Fatalf("expected the id entered, received %v", actual.Id)
+		}
+		return nil
+	}
+	if err := a.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if !a.prompter.(*mocks.Prompter).InputFnCalled ||
+		!a.marksService.(*mocks.MarkService).CreateFnCalled {
+		t.Fatal("input and create should be called")
+	}
+}
+
+func TestAddMarkFetchFailsWithoutId(t *testing.T) {
+	a := newTestAddRunner()
+	a.args.id = ""
+	a.args.url = "https://example.com"
+	a.args.fetch = true
+	a.fetcher.(*mocks.Fetcher).FetchFn = func(string) (*marks.Page, error) {
+		return nil, errors.New("timeout")
+	}
+	if err := a.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if !a.printer.(*mocks.Printer).ErrorFnCalled ||
+		a.marksService.(*mocks.MarkService).CreateFnCalled {
+		t.Fatal("error should be printed without creating")
+	}
+}
+
+func TestAddMarkFetchDuplicateCanonicalUrl(t *testing.T) {
+	a := newTestAddRunner()
+	a.args.url = "https://abc.net.au/news/story?from=rss"
+	a.args.fetch = true
+	a.args.strict = true
+	a.fetcher.(*mocks.Fetcher).FetchFn = func(string) (*marks.Page, error) {
+		return &marks.Page{Title: "Abc", CanonicalUrl: "https://www.abc.net.au/news/"}, nil
+	}
+	if err := a.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if !a.printer.(*mocks.Printer).ErrorFnCalled ||
+		a.marksService.(*mocks.MarkService).CreateFnCalled {
+		t.Fatal("error should be printed without creating")
+	}
+}
+
+func TestAddMarkWithBrowser(t *testing.T) {
+	a := newTestAddRunner()
+	a.args.url = "https://www.example.com"
+	a.args.browser = "Firefox"
+	a.config.Browsers = map[string]*marks.Browser{"firefox": &marks.Browser{Name: "firefox"}}
+	a.marksService.(*mocks.MarkService).CreateFn = func(actual *marks.Mark) error {
+		if actual.Browser != "firefox" {
+			t.Fatalf("expected firefox, received %v", actual.Browser)
+		}
+		return nil
+	}
+	if err := a.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if !a.marksService.(*mocks.MarkService).CreateFnCalled {
+		t.Fatal("create should be called")
+	}
+}
+
+func TestAddMarkWithUnknownBrowser(t *testing.T) {
+	a := newTestAddRunner()
+	a.args.url = "https://www.example.com"
+	a.args.browser = "lynx"
+	a.config.Browsers = map[string]*marks.Browser{"firefox": &marks.Browser{Name: "firefox"}}
+	if err := a.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if !a.printer.(*mocks.Printer).ErrorFnCalled ||
+		a.marksService.(*mocks.MarkService).CreateFnCalled {
+		t.Fatal("error should be printed without creating")
+	}
+}
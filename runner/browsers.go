@@ -0,0 +1,50 @@
+package runner
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/tomguerney/marks/marks"
+)
+
+type listBrowsers struct {
+	config  *marks.Config
+	printer marks.Printer
+}
+
+func NewListBrowsersRunner(
+	config *marks.Config,
+	printer marks.Printer,
+) *listBrowsers {
+	return &listBrowsers{
+		config,
+		printer,
+	}
+}
+
+func (l *listBrowsers) Run() error {
+
+	browsers := []*marks.Browser{}
+	for _, browser := range l.config.Browsers {
+		browsers = append(browsers, browser)
+	}
+	sort.Slice(browsers, func(i, j int) bool {
+		return browsers[i].Name < browsers[j].Name
+	})
+
+	table, err := l.printer.TabulateBrowsers(browsers)
+	if err != nil {
+		return err
+	}
+
+	l.printer.Msg("%v", strings.Join(table, "\n"))
+
+	selected, err := l.printer.Browser(l.config.Browser)
+	if err != nil {
+		return err
+	}
+
+	l.printer.Msg("Selected browser: %v", selected)
+
+	return l.printer.RecordBrowsers("browsers", browsers)
+}
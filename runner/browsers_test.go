@@ -0,0 +1,45 @@
+package runner
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/tomguerney/marks/marks"
+	"github.com/tomguerney/marks/mocks"
+)
+
+func TestListBrowsers(t *testing.T) {
+	config := mocks.NewConfig()
+	config.Browser = "lynx"
+	config.Browsers = map[string]*marks.Browser{
+		"lynx":    &marks.Browser{Name: "lynx", Command: "lynx {{.Url}}"},
+		"default": &marks.Browser{Name: "default", Command: "xdg-open {{.Url}}"},
+		"brave":   &marks.Browser{Name: "brave", Command: "brave-browser {{.Url}}"},
+	}
+	printer := mocks.NewPrinter()
+	var names []string
+	printer.TabulateBrowsersFn = func(browsers []*marks.Browser) ([]string, error) {
+		for _, browser := range browsers {
+			names = append(names, browser.Name)
+		}
+		return []string{}, nil
+	}
+	recorded := 0
+	printer.RecordBrowsersFn = func(action string, browsers []*marks.Browser) error {
+		recorded = len(browsers)
+		return nil
+	}
+	if err := NewListBrowsersRunner(config, printer).Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+	expected := []string{"brave", "default", "lynx"}
+	if !reflect.DeepEqual(names, expected) {
+		t.Fatalf("expected %v, received %v", expected, names)
+	}
+	if !printer.BrowserFnCalled || !printer.MsgFnCalled {
+		t.Fatal("browsers and the selected browser should be printed")
+	}
+	if recorded != 3 {
+		t.Fatalf("expected 3 browsers to be recorded, received %v", recorded)
+	}
+}
@@ -0,0 +1,334 @@
+package runner
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/tomguerney/marks/marks"
+)
+
+type bulkEdit struct {
+	*runner
+	args   *EditArgs
+	editor marks.Editor
+}
+
+const tableHeader = `# Edit the table below and save to apply the changes.
+# Columns are separated by "|": key | id | url | tags, with tags separated by commas.
+# Write a "|" or "," that is part of a value as "\|" or "\," and a "\" as "\\".
+# Change a row to update or rename its bookmark, delete a row to delete the
+# bookmark, or add a row with an empty key to create one. Changes are shown
+# for confirmation before they are saved. Delete everything to cancel.
+`
+
+func NewBulkEditRunner(
+	args *EditArgs,
+	config *marks.Config,
+	markService marks.MarkService,
+	printer marks.Printer,
+	prompter marks.Prompter,
+	editor marks.Editor,
+) *bulkEdit {
+	return &bulkEdit{
+		newRunner(config, markService, printer, prompter),
+		args,
+		editor,
+	}
+}
+
+func (b *bulkEdit) Run() error {
+
+	expr, id := withSavedSearch(b.args.query, b.args.id)
+	selected, err := search(b.markService, expr, id, b.args.url, b.args.tags)
+	if err != nil {
+		return err
+	}
+
+	all, err := b.markService.Marks()
+	if err != nil {
+		return err
+	}
+
+	document := tableDocument(selected)
+	var changeset *marks.Changeset
+	for {
+		document, err = b.editor.Edit(document, ".txt")
+		if err != nil {
+			return err
+		}
+		document = stripEditErrors(document)
+		if strings.TrimSpace(uncommented(document)) == "" {
+			b.printer.Msg("Edit cancelled")
+			return nil
+		}
+		var errs []*editError
+		changeset, errs = b.parseTable(document, selected, all)
+		if len(errs) == 0 {
+			break
+		}
+		document = annotateTableErrors(document, errs)
+	}
+
+	if changeset.Empty() {
+		b.printer.Msg("No changes made")
+		return b.printer.Record("edit")
+	}
+
+	if err := b.preview(changeset, selected); err != nil {
+		return err
+	}
+
+	if !b.prompter.Confirm("Are you sure you want to continue") {
+		b.printer.Msg("Exiting")
+		return nil
+	}
+
+	if err := b.markService.Apply(changeset); err != nil {
+		return err
+	}
+
+	b.printer.Msg(
+		"Created %v, updated %v and deleted %v bookmarks",
+		len(changeset.Creates), len(changeset.Updates), len(changeset.Deletes),
+	)
+
+	changed := append([]*marks.Mark{}, changeset.Creates...)
+	for _, m := range selected {
+		if updated, ok := changeset.Updates[m.Id]; ok {
+			changed = append(changed, updated)
+		}
+	}
+
+	return b.printer.Record("edit", changed...)
+}
+
+// preview shows the changes grouped by kind, in the order of the table.
+func (b *bulkEdit) preview(changeset *marks.Changeset, selected []*marks.Mark) error {
+	updated := []*marks.Mark{}
+	renames := []string{}
+	for _, m := range selected {
+		if u, ok := changeset.Updates[m.Id]; ok {
+			updated = append(updated, u)
+			if u.Id != m.Id {
+				renames = append(renames, fmt.Sprintf("  \"%v\" to \"%v\"", m.Id, u.Id))
+			}
+		}
+	}
+	deleted := []*marks.Mark{}
+	for _, id := range changeset.Deletes {
+		for _, m := range selected {
+			if m.Id == id {
+				deleted = append(deleted, m)
+			}
+		}
+	}
+	groups := []struct {
+		heading string
+		mks     []*marks.Mark
+	}{
+		{"Create", changeset.Creates},
+		{"Update", updated},
+		{"Delete", deleted},
+	}
+	for _, group := range groups {
+		if len(group.mks) == 0 {
+			continue
+		}
+		table, err := b.printer.Tabulate(group.mks)
+		if err != nil {
+			return err
+		}
+		b.printer.Msg("%v %v bookmarks:", group.heading, len(group.mks))
+		b.printer.Msg("%v", strings.Join(table, "\n"))
+	}
+	if len(renames) > 0 {
+		b.printer.Msg("Rename %v bookmarks:\n%v", len(renames), strings.Join(renames, "\n"))
+	}
+	return nil
+}
+
+func tableDocument(mks []*marks.Mark) string {
+	rows := [][]string{}
+	widths := make([]int, 3)
+	for i, m := range mks {
+		tags := []string{}
+		for _, tag := range m.Tags {
+			tags = append(tags, escapeCell(tag, "|,"))
+		}
+		row := []string{strconv.Itoa(i + 1), escapeCell(m.Id, "|"), escapeCell(m.Url, "|"), strings.Join(tags, ", ")}
+		for j := range widths {
+			if len(row[j]) > widths[j] {
+				widths[j] = len(row[j])
+			}
+		}
+		rows = append(rows, row)
+	}
+	lines := []string{}
+	for _, row := range rows {
+		cells := []string{}
+		for j, cell := range row[:3] {
+			cells = append(cells, cell+strings.Repeat(" ", widths[j]-len(cell)))
+		}
+		cells = append(cells, row[3])
+		lines = append(lines, strings.TrimRight(strings.Join(cells, " | "), " "))
+	}
+	return tableHeader + strings.Join(lines, "\n") + "\n"
+}
+
+// parseTable compares the edited table with the selected marks. Errors are
+// reported against the line they were found on.
+func (b *bulkEdit) parseTable(document string, selected, all []*marks.Mark) (*marks.Changeset, []*editError) {
+	changeset := &marks.Changeset{Updates: map[string]*marks.Mark{}}
+	errs := []*editError{}
+	normalizer := marks.NewTagNormalizer(b.config)
+
+	owners := map[string]bool{}
+	for _, m := range selected {
+		owners[strings.ToLower(m.Id)] = true
+	}
+	taken := map[string]bool{}
+	for _, m := range all {
+		if !owners[strings.ToLower(m.Id)] {
+			taken[strings.ToLower(m.Id)] = true
+		}
+	}
+
+	kept := map[int]bool{}
+	for i, line := range strings.Split(document, "\n") {
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(strings.TrimSpace(line), "#") {
+			continue
+		}
+		cells := splitCells(line, '|')
+		if len(cells) == 3 {
+			cells = append([]string{""}, cells...)
+		}
+		if len(cells) != 4 {
+			errs = append(errs, &editError{i, "expected key | id | url | tags"})
+			continue
+		}
+		for j := range cells {
+			cells[j] = strings.TrimSpace(cells[j])
+		}
+		key, id, url := cells[0], unescapeCell(cells[1]), unescapeCell(cells[2])
+		tags := []string{}
+		for _, tag := range splitCells(cells[3], ',') {
+			tags = append(tags, unescapeCell(tag))
+		}
+		tags = normalizer.NormalizeAll(tags)
+
+		if id == "" {
+			errs = append(errs, &editError{i, "id is required"})
+			continue
+		}
+		if taken[strings.ToLower(id)] {
+			errs = append(errs, &editError{i, fmt.Sprintf("a bookmark with id \"%v\" already exists", id)})
+		}
+		taken[strings.ToLower(id)] = true
+
+		if key == "" {
+			if err := validateUrl(url); err != nil {
+				errs = append(errs, &editError{i, err.Error()})
+			}
+			changeset.Creates = append(changeset.Creates, &marks.Mark{Id: id, Url: url, Tags: tags, Created: marks.Timestamp(now())})
+			continue
+		}
+
+		n, err := strconv.Atoi(key)
+		if err != nil || n < 1 || n > len(selected) {
+			errs = append(errs, &editError{i, fmt.Sprintf("key \"%v\" does not match a bookmark; leave it empty to create one", key)})
+			continue
+		}
+		if kept[n] {
+			errs = append(errs, &editError{i, fmt.Sprintf("key \"%v\" is used more than once", key)})
+			continue
+		}
+		kept[n] = true
+
+		original := selected[n-1]
+		if url != original.Url {
+			if err := validateUrl(url); err != nil {
+				errs = append(errs, &editError{i, err.Error()})
+			}
+		}
+		updated := original.Copy()
+		updated.Id, updated.Url, updated.Tags = id, url, tags
+		if !sameEditable(original, updated) {
+			updated.Updated = marks.Timestamp(now())
+			changeset.Updates[original.Id] = updated
+		}
+	}
+
+	for i, m := range selected {
+		if !kept[i+1] {
+			changeset.Deletes = append(changeset.Deletes, m.Id)
+		}
+	}
+
+	return changeset, errs
+}
+
+// annotateTableErrors adds each error as a comment above the line it was
+// found on.
+func annotateTableErrors(document string, errs []*editError) string {
+	byLine := map[int][]string{}
+	for _, err := range errs {
+		byLine[err.entry] = append(byLine[err.entry], editErrorPrefix+err.msg)
+	}
+	annotated := []string{}
+	for i, line := range strings.Split(document, "\n") {
+		annotated = append(annotated, byLine[i]...)
+		annotated = append(annotated, line)
+	}
+	return strings.Join(annotated, "\n")
+}
+
+// escapeCell escapes backslashes and the separators in a value written to
+// the table, so that values containing them read back unchanged.
+func escapeCell(value, separators string) string {
+	builder := strings.Builder{}
+	for _, r := range value {
+		if r == '\\' || strings.ContainsRune(separators, r) {
+			builder.WriteRune('\\')
+		}
+		builder.WriteRune(r)
+	}
+	return builder.String()
+}
+
+// splitCells splits text on the separators that are not escaped, leaving
+// the escapes in place for unescapeCell.
+func splitCells(text string, sep rune) []string {
+	cells := []string{}
+	builder := strings.Builder{}
+	escaped := false
+	for _, r := range text {
+		switch {
+		case escaped:
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == sep:
+			cells = append(cells, builder.String())
+			builder.Reset()
+			continue
+		}
+		builder.WriteRune(r)
+	}
+	return append(cells, builder.String())
+}
+
+// unescapeCell trims a cell and removes its escapes.
+func unescapeCell(cell string) string {
+	builder := strings.Builder{}
+	escaped := false
+	for _, r := range strings.TrimSpace(cell) {
+		if r == '\\' && !escaped {
+			escaped = true
+			continue
+		}
+		escaped = false
+		builder.WriteRune(r)
+	}
+	return builder.String()
+}
@@ -0,0 +1,154 @@
+package runner
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/tomguerney/marks/marks"
+	"github.com/tomguerney/marks/mocks"
+)
+
+func newTestBulkEditRunner() *bulkEdit {
+	b := &bulkEdit{
+		runner: newTestRunner(),
+		args:   &EditArgs{tags: []string{"news"}},
+		editor: mocks.NewEditor(),
+	}
+	b.markService.(*mocks.MarkService).FilterFn = func(string, string, []string) ([]*marks.Mark, error) {
+		return editMarks, nil
+	}
+	b.markService.(*mocks.MarkService).MarksFn = func() ([]*marks.Mark, error) {
+		return append([]*marks.Mark{&marks.Mark{Id: "Google"}}, editMarks...), nil
+	}
+	return b
+}
+
+func TestBulkEditDocument(t *testing.T) {
+	expected := tableHeader +
+		"1 | Abc News | https://www.abc.net.au/news/ | news\n" +
+		"2 | BBC News | https://www.bbc.com/news     | news, uk\n"
+	if actual := tableDocument(editMarks); actual != expected {
+		t.Fatalf("expected %q, received %q", expected, actual)
+	}
+}
+
+func TestBulkEditRoundTripsSeparators(t *testing.T) {
+	b := newTestBulkEditRunner()
+	piped := []*marks.Mark{
+		&marks.Mark{Id: "a|b", Url: "https://example.com/?q=1|2", Tags: []string{"x,y", "c:\\temp"}},
+	}
+	document := tableDocument(piped)
+	changeset, errs := b.parseTable(document, piped, piped)
+	if len(errs) != 0 || !changeset.Empty() {
+		t.Fatalf("expected no changes or errors, received %v %v in %v", changeset, errs, document)
+	}
+	edited := strings.Replace(document, "x\\,y", "x\\,z", 1)
+	changeset, errs = b.parseTable(edited, piped, piped)
+	if len(errs) != 0 {
+		t.Fatalf("unexpected errors %v", errs)
+	}
+	updated := changeset.Updates["a|b"]
+	expected := []string{"x,z", "c:\\temp"}
+	if updated == nil || updated.Url != piped[0].Url || !reflect.DeepEqual(updated.Tags, expected) {
+		t.Fatalf("expected tags %v, received %v", expected, updated)
+	}
+}
+
+func TestBulkEdit(t *testing.T) {
+	b := newTestBulkEditRunner()
+	b.editor.(*mocks.Editor).EditFn = func(text, ext string) (string, error) {
+		return tableHeader +
+			"2 | BBC | https://www.bbc.com/news | news, britain\n" +
+			"  | Guardian | https://www.theguardian.com | news\n", nil
+	}
+	b.markService.(*mocks.MarkService).ApplyFn = func(c *marks.Changeset) error {
+		expected := &marks.Changeset{
+			Creates: []*marks.Mark{
+				&marks.Mark{Id: "Guardian", Url: "https://www.theguardian.com", Tags: []string{"news"}, Created: marks.Timestamp(testNow)},
+			},
+			Updates: map[string]*marks.Mark{
+				"BBC News": &marks.Mark{Id: "BBC", Url: "https://www.bbc.com/news", Tags: []string{"news", "britain"}, Updated: marks.Timestamp(testNow)},
+			},
+			Deletes: []string{"Abc News"},
+		}
+		if !reflect.DeepEqual(c, expected) {
+			t.Fatalf("expected %v, received %v", expected, c)
+		}
+		return nil
+	}
+	if err := b.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if !b.prompter.(*mocks.Prompter).ConfirmFnCalled ||
+		!b.markService.(*mocks.MarkService).ApplyFnCalled {
+		t.Fatal("changes should be confirmed and applied")
+	}
+}
+
+func TestBulkEditReopensWithErrors(t *testing.T) {
+	b := newTestBulkEditRunner()
+	calls := 0
+	b.editor.(*mocks.Editor).EditFn = func(text, ext string) (string, error) {
+		calls++
+		switch calls {
+		case 1:
+			return tableHeader +
+				"1 | google | https://www.abc.net.au/news/ | news\n" +
+				"1 | BBC News | https://www.bbc.com/news | news\n" +
+				"  | Guardian | theguardian.com | news\n" +
+				"Broken line\n", nil
+		case 2:
+			for _, expected := range []string{
+				editErrorPrefix + "a bookmark with id \"google\" already exists\n1 | google",
+				editErrorPrefix + "key \"1\" is used more than once\n1 | BBC News",
+				editErrorPrefix + "url \"theguardian.com\" must include a scheme and host, e.g. https://theguardian.com\n  | Guardian",
+				editErrorPrefix + "expected key | id | url | tags\nBroken line",
+			} {
+				if !strings.Contains(text, expected) {
+					t.Fatalf("expected %q in %v", expected, text)
+				}
+			}
+			return tableDocument(editMarks), nil
+		default:
+			t.Fatal("editor should only open twice")
+			return "", nil
+		}
+	}
+	if err := b.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if b.markService.(*mocks.MarkService).ApplyFnCalled {
+		t.Fatal("apply should not be called without changes")
+	}
+}
+
+func TestBulkEditNotConfirmed(t *testing.T) {
+	b := newTestBulkEditRunner()
+	b.editor.(*mocks.Editor).EditFn = func(text, ext string) (string, error) {
+		return tableHeader + "1 | Abc News | https://www.abc.net.au/news/ | news\n", nil
+	}
+	b.prompter.(*mocks.Prompter).ConfirmFn = func(string) bool {
+		return false
+	}
+	if err := b.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if b.markService.(*mocks.MarkService).ApplyFnCalled {
+		t.Fatal("apply should not be called")
+	}
+}
+
+func TestBulkEditCancelled(t *testing.T) {
+	b := newTestBulkEditRunner()
+	b.editor.(*mocks.Editor).EditFn = func(text, ext string) (string, error) {
+		return tableHeader, nil
+	}
+	if err := b.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if b.prompter.(*mocks.Prompter).ConfirmFnCalled ||
+		b.markService.(*mocks.MarkService).ApplyFnCalled {
+		t.Fatal("edit should be cancelled")
+	}
+}
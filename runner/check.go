@@ -0,0 +1,107 @@
+package runner
+
+import (
+	"strings"
+
+	"github.com/tomguerney/marks/marks"
+)
+
+type check struct {
+	args        *CheckArgs
+	config      *marks.Config
+	markService marks.MarkService
+	printer     marks.Printer
+	checker     checker
+}
+
+type CheckArgs struct {
+	id        string
+	url       string
+	tags      []string
+	tagBroken bool
+}
+
+type checker interface {
+	Check([]*marks.Mark) []*marks.LinkStatus
+}
+
+const brokenTag = "broken"
+
+func NewCheckRunner(
+	args *CheckArgs,
+	config *marks.Config,
+	markService marks.MarkService,
+	printer marks.Printer,
+	checker checker,
+) *check {
+	return &check{
+		args,
+		config,
+		markService,
+		printer,
+		checker,
+	}
+}
+
+func NewCheckArgs(id, url string, tags []string, tagBroken bool) *CheckArgs {
+	return &CheckArgs{id, url, tags, tagBroken}
+}
+
+func (c *check) Run() error {
+	filtered, err := c.markService.Filter(c.args.id, c.args.url, c.args.tags)
+	if err != nil {
+		return err
+	}
+	withUrls := []*marks.Mark{}
+	for _, m := range filtered {
+		if m.Url != "" && !marks.IsUrlTemplate(m.Url) {
+			withUrls = append(withUrls, m)
+		}
+	}
+	if len(withUrls) == 0 {
+		c.printer.Msg("No bookmarks found")
+		return c.printer.Record("check")
+	}
+	statuses := c.checker.Check(withUrls)
+	table, err := c.printer.TabulateLinkStatuses(statuses)
+	if err != nil {
+		return err
+	}
+	c.printer.Msg("%v", strings.Join(table, "\n"))
+	broken := []*marks.Mark{}
+	for _, status := range statuses {
+		if status.Broken() {
+			broken = append(broken, status.Mark)
+		}
+	}
+	c.printer.Msg("%v of %v bookmarks are broken", len(broken), len(statuses))
+	if c.args.tagBroken {
+		if err := c.tagBroken(broken); err != nil {
+			return err
+		}
+	}
+	return c.printer.Record("check", broken...)
+}
+
+// tagBroken tags the broken marks that are not tagged already, saving them
+// together so that either all are tagged or none.
+func (c *check) tagBroken(broken []*marks.Mark) error {
+	updates := map[string]*marks.Mark{}
+	for _, m := range broken {
+		if m.ContainsTag(brokenTag) {
+			continue
+		}
+		updated := m.Copy()
+		updated.Tags = append(updated.Tags, brokenTag)
+		updated.Updated = marks.Timestamp(now())
+		updates[m.Id] = updated
+	}
+	if len(updates) == 0 {
+		return nil
+	}
+	if err := c.markService.UpdateAll(updates); err != nil {
+		return err
+	}
+	c.printer.Msg("Tagged %v bookmarks as \"%v\"", len(updates), brokenTag)
+	return nil
+}
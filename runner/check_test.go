@@ -0,0 +1,113 @@
+package runner
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/tomguerney/marks/marks"
+	"github.com/tomguerney/marks/mocks"
+)
+
+func newTestCheckRunner() *check {
+	return &check{
+		args:        &CheckArgs{},
+		config:      mocks.NewConfig(),
+		markService: mocks.NewMarkService(),
+		printer:     mocks.NewPrinter(),
+		checker:     mocks.NewChecker(),
+	}
+}
+
+func brokenCheckFn(mks []*marks.Mark) []*marks.LinkStatus {
+	return []*marks.LinkStatus{
+		&marks.LinkStatus{Mark: mks[0], StatusCode: 404},
+		&marks.LinkStatus{Mark: mks[1], StatusCode: 200},
+	}
+}
+
+func TestCheckSuccess(t *testing.T) {
+	r := newTestCheckRunner()
+	if err := r.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if !r.checker.(*mocks.Checker).CheckFnCalled ||
+		!r.printer.(*mocks.Printer).TabulateLinkStatusesFnCalled {
+		t.Fatal("check and tabulate link statuses should be called")
+	}
+	if r.markService.(*mocks.MarkService).UpdateAllFnCalled {
+		t.Fatal("update all should not be called")
+	}
+}
+
+func TestCheckSkipsMarksWithoutUrls(t *testing.T) {
+	r := newTestCheckRunner()
+	filterFn := func(string, string, []string) ([]*marks.Mark, error) {
+		return []*marks.Mark{&marks.Mark{Id: "no url"}}, nil
+	}
+	r.markService.(*mocks.MarkService).FilterFn = filterFn
+	if err := r.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if r.checker.(*mocks.Checker).CheckFnCalled {
+		t.Fatal("check should not be called")
+	}
+}
+
+func TestCheckTagBroken(t *testing.T) {
+	r := newTestCheckRunner()
+	r.args.tagBroken = true
+	updateAllFn := func(updates map[string]*marks.Mark) error {
+		original := mocks.DefaultMarks[0]
+		if len(updates) != 1 {
+			t.Fatalf("expected only %v to be updated, received %v", original.Id, updates)
+		}
+		actual := updates[original.Id]
+		expected := []string{"news", "current affairs", "broken"}
+		if !reflect.DeepEqual(actual.Tags, expected) {
+			t.Fatalf("expected %v, received %v", expected, actual.Tags)
+		}
+		if !actual.UpdatedAt().Equal(testNow) {
+			t.Fatalf("expected updated %v, received %v", testNow, actual.Updated)
+		}
+		if len(original.Tags) != 2 {
+			t.Fatal("original mark should not be modified")
+		}
+		return nil
+	}
+	r.checker.(*mocks.Checker).CheckFn = brokenCheckFn
+	r.markService.(*mocks.MarkService).UpdateAllFn = updateAllFn
+	if err := r.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if !r.markService.(*mocks.MarkService).UpdateAllFnCalled {
+		t.Fatal("update all should be called")
+	}
+	if r.markService.(*mocks.MarkService).UpdateFnCalled {
+		t.Fatal("marks should be saved together")
+	}
+}
+
+func TestCheckBrokenWithoutTagBroken(t *testing.T) {
+	r := newTestCheckRunner()
+	r.checker.(*mocks.Checker).CheckFn = brokenCheckFn
+	if err := r.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if r.markService.(*mocks.MarkService).UpdateAllFnCalled {
+		t.Fatal("update all should not be called")
+	}
+}
+
+func TestCheckTagBrokenUpdateError(t *testing.T) {
+	r := newTestCheckRunner()
+	r.args.tagBroken = true
+	updateAllFn := func(map[string]*marks.Mark) error {
+		return errors.New("error")
+	}
+	r.checker.(*mocks.Checker).CheckFn = brokenCheckFn
+	r.markService.(*mocks.MarkService).UpdateAllFn = updateAllFn
+	if err := r.Run(); err == nil {
+		t.Fatal("should return error")
+	}
+}
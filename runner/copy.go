@@ -1,6 +1,8 @@
 package runner
 
 import (
+	"fmt"
+
 	"github.com/tomguerney/marks/marks"
 )
 
@@ -11,9 +13,10 @@ type copyRunner struct {
 }
 
 type CopyArgs struct {
-	id   string
-	url  string
-	tags []string
+	id    string
+	url   string
+	tags  []string
+	query string
 }
 
 type clipper interface {
@@ -40,13 +43,13 @@ func NewCopyRunner(
 	}
 }
 
-func NewCopyArgs(id, url string, tags []string) *CopyArgs {
-	return &CopyArgs{id, url, tags}
+func NewCopyArgs(id, url string, tags []string, query string) *CopyArgs {
+	return &CopyArgs{id, url, tags, query}
 }
 
 func (c *copyRunner) Run() error {
 
-	selected, err := c.filter("Select bookmark to copy", c.args.id, c.args.url, c.args.tags)
+	selected, err := c.filter("Select bookmark to copy", c.args.query, c.args.id, c.args.url, c.args.tags)
 
 	if err != nil {
 		if err, ok := err.(*runnerError); ok {
@@ -57,17 +60,28 @@ func (c *copyRunner) Run() error {
 		}
 	}
 
-	err = c.clipper.Copy(selected.Url)
+	url, err := marks.ExpandUrl(selected.Url, nil, func(label string) (string, error) {
+		return c.prompter.Input(fmt.Sprintf("%v for %v", label, selected.Id))
+	})
+	if err != nil {
+		return err
+	}
+
+	err = c.clipper.Copy(url)
 	if err != nil {
 		return err
 	}
 
-	printUrl, err := c.printer.Url(selected.Url)
+	if err := c.touch(selected); err != nil {
+		return err
+	}
+
+	printUrl, err := c.printer.Url(url)
 	if err != nil {
 		return err
 	}
 
 	c.printer.Msg("Url copied to clipboard: %v", printUrl)
 
-	return nil
+	return c.printer.Record("copy", selected)
 }
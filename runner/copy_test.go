@@ -97,3 +97,29 @@ func TestCopyCopyError(t *testing.T) {
 		t.Fatal("Run should return error")
 	}
 }
+
+func TestCopyExpandsUrlTemplate(t *testing.T) {
+	m := &marks.Mark{Id: "jira", Url: "https://jira.example.com/browse/{{.Args 0}}"}
+	r := newTestCopyRunner()
+	r.markService.(*mocks.MarkService).FilterFn = func(string, string, []string) ([]*marks.Mark, error) {
+		return []*marks.Mark{m}, nil
+	}
+	r.prompter.(*mocks.Prompter).InputFn = func(label string) (string, error) {
+		if label != "Argument 0 for jira" {
+			t.Fatalf("unexpected label %v", label)
+		}
+		return "PROJ-7", nil
+	}
+	r.clipper.(*mocks.Clipper).CopyFn = func(actual string) error {
+		if actual != "https://jira.example.com/browse/PROJ-7" {
+			t.Fatalf("unexpected url %v", actual)
+		}
+		return nil
+	}
+	if err := r.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if !r.clipper.(*mocks.Clipper).CopyFnCalled {
+		t.Fatal("copy should be called")
+	}
+}
@@ -0,0 +1,96 @@
+package runner
+
+import (
+	"github.com/tomguerney/marks/marks"
+)
+
+type dedupe struct {
+	*runner
+}
+
+func NewDedupeRunner(
+	config *marks.Config,
+	markService marks.MarkService,
+	printer marks.Printer,
+	prompter marks.Prompter,
+) *dedupe {
+	return &dedupe{
+		newRunner(config, markService, printer, prompter),
+	}
+}
+
+func (d *dedupe) Run() error {
+
+	mks, err := d.markService.Marks()
+	if err != nil {
+		return err
+	}
+
+	groups := marks.Duplicates(mks)
+
+	if len(groups) == 0 {
+		d.printer.Msg("No duplicate bookmarks found")
+		return d.printer.Record("dedupe")
+	}
+
+	merged := []*marks.Mark{}
+	for _, group := range groups {
+		m, err := d.merge(group)
+		if err != nil {
+			return err
+		}
+		if m != nil {
+			merged = append(merged, m)
+		}
+	}
+
+	d.printer.Msg("Merged %v of %v groups of duplicates", len(merged), len(groups))
+
+	return d.printer.Record("dedupe", merged...)
+}
+
+// merge asks which mark in the group to keep, folds the others into it and
+// deletes them, saving both at once. It returns nil if the group was
+// skipped.
+func (d *dedupe) merge(group []*marks.Mark) (*marks.Mark, error) {
+
+	table, err := d.printer.Tabulate(group)
+	if err != nil {
+		return nil, err
+	}
+
+	d.printer.Msg("%v bookmarks point to %v", len(group), marks.CanonicalUrl(group[0].Url))
+
+	i, err := d.prompter.Select("Select bookmark to keep", append(table, "Skip"))
+	if err != nil {
+		return nil, err
+	}
+
+	if i >= len(group) {
+		return nil, nil
+	}
+
+	keep := group[i]
+	others := []*marks.Mark{}
+	deletes := []string{}
+	for j, m := range group {
+		if j != i {
+			others = append(others, m)
+			deletes = append(deletes, m.Id)
+		}
+	}
+
+	merged := marks.Merge(keep, others)
+	merged.Updated = marks.Timestamp(now())
+
+	changeset := &marks.Changeset{
+		Updates: map[string]*marks.Mark{keep.Id: merged},
+		Deletes: deletes,
+	}
+
+	if err := d.markService.Apply(changeset); err != nil {
+		return nil, err
+	}
+
+	return merged, nil
+}
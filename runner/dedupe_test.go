@@ -0,0 +1,112 @@
+package runner
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/tomguerney/marks/marks"
+	"github.com/tomguerney/marks/mocks"
+)
+
+func newTestDedupeRunner(mks []*marks.Mark) *dedupe {
+	d := &dedupe{newTestRunner()}
+	d.markService.(*mocks.MarkService).MarksFn = func() ([]*marks.Mark, error) {
+		return mks, nil
+	}
+	d.printer.(*mocks.Printer).TabulateFn = func(group []*marks.Mark) ([]string, error) {
+		table := []string{}
+		for _, m := range group {
+			table = append(table, m.Id)
+		}
+		return table, nil
+	}
+	return d
+}
+
+func TestDedupe(t *testing.T) {
+	older := testNow.AddDate(-1, 0, 0)
+	d := newTestDedupeRunner([]*marks.Mark{
+		&marks.Mark{Id: "abc", Url: "https://www.abc.net.au/news/", Tags: []string{"news"}, Created: marks.Timestamp(testNow)},
+		&marks.Mark{Id: "bbc", Url: "https://www.bbc.com/news"},
+		&marks.Mark{Id: "abc au", Url: "http://abc.net.au/news", Tags: []string{"au"}, Created: marks.Timestamp(older)},
+	})
+	d.prompter.(*mocks.Prompter).SelectFn = func(label string, items []string) (int, error) {
+		if len(items) != 3 || items[2] != "Skip" {
+			t.Fatalf("expected two bookmarks and skip, received %v", items)
+		}
+		return 1, nil
+	}
+	d.markService.(*mocks.MarkService).ApplyFn = func(c *marks.Changeset) error {
+		expected := &marks.Changeset{
+			Updates: map[string]*marks.Mark{
+				"abc au": &marks.Mark{
+					Id:      "abc au",
+					Url:     "http://abc.net.au/news",
+					Tags:    []string{"au", "news"},
+					Created: marks.Timestamp(older),
+					Updated: marks.Timestamp(testNow),
+				},
+			},
+			Deletes: []string{"abc"},
+		}
+		if !reflect.DeepEqual(c, expected) {
+			t.Fatalf("expected %v, received %v", expected, c)
+		}
+		return nil
+	}
+	if err := d.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if !d.markService.(*mocks.MarkService).ApplyFnCalled {
+		t.Fatal("apply should be called")
+	}
+}
+
+func TestDedupeKeepsNotesOfDeleted(t *testing.T) {
+	d := newTestDedupeRunner([]*marks.Mark{
+		&marks.Mark{Id: "abc", Url: "https://www.abc.net.au/news/"},
+		&marks.Mark{Id: "abc au", Url: "http://abc.net.au/news", Notes: "Election coverage", Browser: "firefox"},
+	})
+	d.prompter.(*mocks.Prompter).SelectFn = func(label string, items []string) (int, error) {
+		return 0, nil
+	}
+	d.markService.(*mocks.MarkService).ApplyFn = func(c *marks.Changeset) error {
+		merged := c.Updates["abc"]
+		if merged.Notes != "Election coverage" || merged.Browser != "firefox" {
+			t.Fatalf("expected the deleted mark's notes and browser, received %v", merged)
+		}
+		if !reflect.DeepEqual(c.Deletes, []string{"abc au"}) {
+			t.Fatalf("expected abc au to be deleted, received %v", c.Deletes)
+		}
+		return nil
+	}
+	if err := d.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+}
+
+func TestDedupeSkip(t *testing.T) {
+	d := newTestDedupeRunner([]*marks.Mark{
+		&marks.Mark{Id: "abc", Url: "https://www.abc.net.au/news/"},
+		&marks.Mark{Id: "abc au", Url: "http://abc.net.au/news"},
+	})
+	d.prompter.(*mocks.Prompter).SelectFn = func(label string, items []string) (int, error) {
+		return 2, nil
+	}
+	if err := d.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if d.markService.(*mocks.MarkService).ApplyFnCalled {
+		t.Fatal("apply should not be called")
+	}
+}
+
+func TestDedupeNoDuplicates(t *testing.T) {
+	d := newTestDedupeRunner(mocks.DefaultMarks)
+	if err := d.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if d.prompter.(*mocks.Prompter).SelectFnCalled {
+		t.Fatal("select should not be called")
+	}
+}
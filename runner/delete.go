@@ -10,9 +10,10 @@ type deleteRunner struct {
 }
 
 type DeleteArgs struct {
-	id   string
-	url  string
-	tags []string
+	id    string
+	url   string
+	tags  []string
+	query string
 }
 
 func NewDeleteRunner(
@@ -33,13 +34,13 @@ func NewDeleteRunner(
 	}
 }
 
-func NewDeleteArgs(id, url string, tags []string) *DeleteArgs {
-	return &DeleteArgs{id, url, tags}
+func NewDeleteArgs(id, url string, tags []string, query string) *DeleteArgs {
+	return &DeleteArgs{id, url, tags, query}
 }
 
 func (d *deleteRunner) Run() error {
 
-	selected, err := d.filter("Select bookmark to delete", d.args.id, d.args.url, d.args.tags)
+	selected, err := d.filter("Select bookmark to delete", d.args.query, d.args.id, d.args.url, d.args.tags)
 
 	if err != nil {
 		if err, ok := err.(*runnerError); ok {
@@ -69,5 +70,5 @@ func (d *deleteRunner) Run() error {
 
 	d.printer.Msg("Deleted")
 
-	return nil
+	return d.printer.Record("delete", selected)
 }
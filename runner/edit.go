@@ -0,0 +1,309 @@
+package runner
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/tomguerney/marks/marks"
+	"gopkg.in/yaml.v2"
+)
+
+type edit struct {
+	*runner
+	args   *EditArgs
+	editor marks.Editor
+}
+
+type EditArgs struct {
+	id    string
+	url   string
+	tags  []string
+	query string
+}
+
+// editableMark is the part of a mark that can be changed in the editor.
+type editableMark struct {
+	Id          string   `yaml:"id"`
+	Url         string   `yaml:"url,omitempty"`
+	Tags        []string `yaml:"tags,omitempty"`
+	Description string   `yaml:"description,omitempty"`
+	Notes       string   `yaml:"notes,omitempty"`
+	Browser     string   `yaml:"browser,omitempty"`
+}
+
+// editError is a problem with the edited document, reported against the
+// entry it was found in, or the whole document if entry is negative.
+type editError struct {
+	entry int
+	msg   string
+}
+
+const editHeader = `# Edit the bookmarks below and save to apply the changes.
+# Entries match bookmarks by position, so do not add, remove or reorder them.
+# Delete everything to cancel.
+`
+
+const editErrorPrefix = "# Error: "
+
+func NewEditRunner(
+	args *EditArgs,
+	config *marks.Config,
+	markService marks.MarkService,
+	printer marks.Printer,
+	prompter marks.Prompter,
+	editor marks.Editor,
+) *edit {
+	return &edit{
+		newRunner(config, markService, printer, prompter),
+		args,
+		editor,
+	}
+}
+
+func NewEditArgs(id, url string, tags []string, query string) *EditArgs {
+	return &EditArgs{id, url, tags, query}
+}
+
+func (e *edit) Run() error {
+
+	expr, id := withSavedSearch(e.args.query, e.args.id)
+	selected, err := search(e.markService, expr, id, e.args.url, e.args.tags)
+	if err != nil {
+		return err
+	}
+
+	if len(selected) == 0 {
+		e.printer.Msg("No bookmarks found")
+		return e.printer.Record("edit")
+	}
+
+	all, err := e.markService.Marks()
+	if err != nil {
+		return err
+	}
+
+	document, err := editDocument(selected)
+	if err != nil {
+		return err
+	}
+
+	var edited []*editableMark
+	for {
+		document, err = e.editor.Edit(document, ".yaml")
+		if err != nil {
+			return err
+		}
+		document = stripEditErrors(document)
+		if strings.TrimSpace(uncommented(document)) == "" {
+			e.printer.Msg("Edit cancelled")
+			return nil
+		}
+		var errs []*editError
+		edited, errs = parseEditDocument(document, selected, all, e.config.Browsers)
+		if len(errs) == 0 {
+			break
+		}
+		document = annotateEditErrors(document, errs)
+	}
+
+	updates := map[string]*marks.Mark{}
+	changed := []*marks.Mark{}
+	normalizer := marks.NewTagNormalizer(e.config)
+	for i, original := range selected {
+		updated := original.Copy()
+		updated.Id = edited[i].Id
+		updated.Url = edited[i].Url
+		updated.Tags = normalizer.NormalizeAll(edited[i].Tags)
+		updated.Description = edited[i].Description
+		updated.Notes = edited[i].Notes
+		updated.Browser = strings.ToLower(edited[i].Browser)
+		if sameEditable(original, updated) {
+			continue
+		}
+		updated.Updated = marks.Timestamp(now())
+		updates[original.Id] = updated
+		changed = append(changed, updated)
+	}
+
+	if len(changed) == 0 {
+		e.printer.Msg("No changes made")
+		return e.printer.Record("edit")
+	}
+
+	if err := e.markService.UpdateAll(updates); err != nil {
+		return err
+	}
+
+	table, err := e.printer.Tabulate(changed)
+	if err != nil {
+		return err
+	}
+
+	e.printer.Msg("Updated %v bookmarks:", len(changed))
+	e.printer.Msg("%v", strings.Join(table, "\n"))
+
+	return e.printer.Record("edit", changed...)
+}
+
+func editDocument(mks []*marks.Mark) (string, error) {
+	editable := []*editableMark{}
+	for _, m := range mks {
+		editable = append(editable, &editableMark{
+			Id:          m.Id,
+			Url:         m.Url,
+			Tags:        m.Tags,
+			Description: m.Description,
+			Notes:       m.Notes,
+			Browser:     m.Browser,
+		})
+	}
+	out, err := yaml.Marshal(editable)
+	if err != nil {
+		return "", err
+	}
+	return editHeader + string(out), nil
+}
+
+// parseEditDocument reads the edited marks back, checking that there is one
+// per selected mark, that ids are present and unique across all marks, that
+// changed urls are absolute and that changed browsers are configured.
+func parseEditDocument(document string, selected, all []*marks.Mark, browsers map[string]*marks.Browser) ([]*editableMark, []*editError) {
+	var edited []*editableMark
+	if err := yaml.UnmarshalStrict([]byte(document), &edited); err != nil {
+		return nil, []*editError{{-1, err.Error()}}
+	}
+	if len(edited) != len(selected) {
+		msg := fmt.Sprintf("expected %v bookmarks, found %v; bookmarks cannot be added or removed here", len(selected), len(edited))
+		return nil, []*editError{{-1, msg}}
+	}
+
+	errs := []*editError{}
+	owners := map[string]bool{}
+	for _, m := range selected {
+		owners[strings.ToLower(m.Id)] = true
+	}
+	taken := map[string]bool{}
+	for _, m := range all {
+		if !owners[strings.ToLower(m.Id)] {
+			taken[strings.ToLower(m.Id)] = true
+		}
+	}
+
+	for i, m := range edited {
+		if m == nil || strings.TrimSpace(m.Id) == "" {
+			errs = append(errs, &editError{i, "id is required"})
+			continue
+		}
+		key := strings.ToLower(m.Id)
+		if taken[key] {
+			errs = append(errs, &editError{i, fmt.Sprintf("a bookmark with id \"%v\" already exists", m.Id)})
+		}
+		taken[key] = true
+		if m.Url != selected[i].Url {
+			if err := validateUrl(m.Url); err != nil {
+				errs = append(errs, &editError{i, err.Error()})
+			}
+		}
+		if !strings.EqualFold(m.Browser, selected[i].Browser) {
+			if err := validateBrowser(m.Browser, browsers); err != nil {
+				errs = append(errs, &editError{i, err.Error()})
+			}
+		}
+	}
+	return edited, errs
+}
+
+// validateBrowser checks that a mark's browser is one of the configured
+// browsers. An empty browser leaves the choice to routes and config.
+func validateBrowser(name string, browsers map[string]*marks.Browser) error {
+	if name == "" {
+		return nil
+	}
+	if _, ok := browsers[strings.ToLower(name)]; ok {
+		return nil
+	}
+	names := []string{}
+	for browser := range browsers {
+		names = append(names, browser)
+	}
+	sort.Strings(names)
+	return errors.New(fmt.Sprintf("browser \"%v\" is not configured, use one of %v", name, strings.Join(names, ", ")))
+}
+
+func validateUrl(raw string) error {
+	if raw == "" {
+		return nil
+	}
+	if marks.IsUrlTemplate(raw) {
+		if err := marks.ValidateUrlTemplate(raw); err != nil {
+			return errors.New(fmt.Sprintf("url template \"%v\" is not valid: %v", raw, err.Error()))
+		}
+		return nil
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return errors.New(fmt.Sprintf("url \"%v\" is not valid: %v", raw, err.Error()))
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return errors.New(fmt.Sprintf("url \"%v\" must include a scheme and host, e.g. https://%v", raw, raw))
+	}
+	return nil
+}
+
+// annotateEditErrors adds each error as a comment above the entry it belongs
+// to, or at the top of the document.
+func annotateEditErrors(document string, errs []*editError) string {
+	lines := strings.Split(document, "\n")
+	byEntry := map[int][]string{}
+	for _, err := range errs {
+		byEntry[err.entry] = append(byEntry[err.entry], editErrorPrefix+strings.ReplaceAll(err.msg, "\n", " "))
+	}
+	annotated := append([]string{}, byEntry[-1]...)
+	entry := 0
+	for _, line := range lines {
+		if strings.HasPrefix(line, "- ") || line == "-" {
+			annotated = append(annotated, byEntry[entry]...)
+			entry++
+		}
+		annotated = append(annotated, line)
+	}
+	return strings.Join(annotated, "\n")
+}
+
+func stripEditErrors(document string) string {
+	lines := []string{}
+	for _, line := range strings.Split(document, "\n") {
+		if !strings.HasPrefix(line, editErrorPrefix) {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func uncommented(document string) string {
+	lines := []string{}
+	for _, line := range strings.Split(document, "\n") {
+		if !strings.HasPrefix(strings.TrimSpace(line), "#") {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func sameEditable(a, b *marks.Mark) bool {
+	if a.Id != b.Id || a.Url != b.Url || a.Description != b.Description || a.Notes != b.Notes || a.Browser != b.Browser {
+		return false
+	}
+	if len(a.Tags) != len(b.Tags) {
+		return false
+	}
+	for i := range a.Tags {
+		if a.Tags[i] != b.Tags[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,163 @@
+package runner
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/tomguerney/marks/marks"
+	"github.com/tomguerney/marks/mocks"
+)
+
+var editMarks = []*marks.Mark{
+	&marks.Mark{Id: "Abc News", Url: "https://www.abc.net.au/news/", Tags: []string{"news"}},
+	&marks.Mark{Id: "BBC News", Url: "https://www.bbc.com/news", Tags: []string{"news", "uk"}},
+}
+
+func newTestEditRunner() *edit {
+	e := &edit{
+		runner: newTestRunner(),
+		args:   &EditArgs{tags: []string{"news"}},
+		editor: mocks.NewEditor(),
+	}
+	e.markService.(*mocks.MarkService).FilterFn = func(string, string, []string) ([]*marks.Mark, error) {
+		return editMarks, nil
+	}
+	e.markService.(*mocks.MarkService).MarksFn = func() ([]*marks.Mark, error) {
+		return append([]*marks.Mark{&marks.Mark{Id: "Google"}}, editMarks...), nil
+	}
+	return e
+}
+
+func TestEdit(t *testing.T) {
+	e := newTestEditRunner()
+	e.editor.(*mocks.Editor).EditFn = func(text, ext string) (string, error) {
+		if ext != ".yaml" || !strings.Contains(text, "- id: BBC News\n  url: https://www.bbc.com/news\n") {
+			t.Fatalf("unexpected document %v", text)
+		}
+		return strings.Replace(text, "- uk", "- britain\n  notes: Public broadcaster", 1), nil
+	}
+	e.markService.(*mocks.MarkService).UpdateAllFn = func(updates map[string]*marks.Mark) error {
+		expected := map[string]*marks.Mark{
+			"BBC News": &marks.Mark{
+				Id:      "BBC News",
+				Url:     "https://www.bbc.com/news",
+				Tags:    []string{"news", "britain"},
+				Notes:   "Public broadcaster",
+				Updated: marks.Timestamp(testNow),
+			},
+		}
+		if !reflect.DeepEqual(updates, expected) {
+			t.Fatalf("expected %v, received %v", expected, updates)
+		}
+		return nil
+	}
+	if err := e.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if !e.markService.(*mocks.MarkService).UpdateAllFnCalled {
+		t.Fatal("update all should be called")
+	}
+}
+
+func TestEditReopensWithErrors(t *testing.T) {
+	e := newTestEditRunner()
+	calls := 0
+	e.editor.(*mocks.Editor).EditFn = func(text, ext string) (string, error) {
+		calls++
+		switch calls {
+		case 1:
+			text = strings.Replace(text, "id: BBC News", "id: google", 1)
+			return strings.Replace(text, "https://www.abc.net.au/news/", "abc.net.au", 1), nil
+		case 2:
+			expected := editErrorPrefix + "url \"abc.net.au\" must include a scheme and host, e.g. https://abc.net.au\n- id: Abc News"
+			if !strings.Contains(text, expected) {
+				t.Fatalf("expected url error above first entry in %v", text)
+			}
+			expected = editErrorPrefix + "a bookmark with id \"google\" already exists\n- id: google"
+			if !strings.Contains(text, expected) {
+				t.Fatalf("expected id error above second entry in %v", text)
+			}
+			text = strings.Replace(text, "id: google", "id: BBC", 1)
+			return strings.Replace(text, "url: abc.net.au", "url: https://abc.net.au", 1), nil
+		default:
+			t.Fatal("editor should only open twice")
+			return "", nil
+		}
+	}
+	e.markService.(*mocks.MarkService).UpdateAllFn = func(updates map[string]*marks.Mark) error {
+		if updates["Abc News"].Url != "https://abc.net.au" || updates["BBC News"].Id != "BBC" {
+			t.Fatalf("unexpected updates %v", updates)
+		}
+		return nil
+	}
+	if err := e.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if !e.markService.(*mocks.MarkService).UpdateAllFnCalled {
+		t.Fatal("update all should be called")
+	}
+}
+
+func TestEditRejectsUnknownBrowser(t *testing.T) {
+	e := newTestEditRunner()
+	e.config.Browsers = map[string]*marks.Browser{"chrome": &marks.Browser{}, "firefox": &marks.Browser{}}
+	calls := 0
+	e.editor.(*mocks.Editor).EditFn = func(text, ext string) (string, error) {
+		calls++
+		switch calls {
+		case 1:
+			return strings.Replace(text, "id: Abc News\n", "id: Abc News\n  browser: chrme\n", 1), nil
+		case 2:
+			expected := editErrorPrefix + "browser \"chrme\" is not configured, use one of chrome, firefox\n- id: Abc News"
+			if !strings.Contains(text, expected) {
+				t.Fatalf("expected browser error above first entry in %v", text)
+			}
+			return strings.Replace(text, "browser: chrme", "browser: Chrome", 1), nil
+		default:
+			t.Fatal("editor should only open twice")
+			return "", nil
+		}
+	}
+	e.markService.(*mocks.MarkService).UpdateAllFn = func(updates map[string]*marks.Mark) error {
+		if updates["Abc News"].Browser != "chrome" {
+			t.Fatalf("unexpected updates %v", updates)
+		}
+		return nil
+	}
+	if err := e.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+}
+
+func TestEditRejectsRemovedEntries(t *testing.T) {
+	document := "- id: Abc News\n"
+	_, errs := parseEditDocument(document, editMarks, editMarks, nil)
+	if len(errs) != 1 || errs[0].entry != -1 {
+		t.Fatalf("expected one document error, received %v", errs)
+	}
+}
+
+func TestEditCancelled(t *testing.T) {
+	e := newTestEditRunner()
+	e.editor.(*mocks.Editor).EditFn = func(text, ext string) (string, error) {
+		return editHeader, nil
+	}
+	if err := e.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if e.markService.(*mocks.MarkService).UpdateAllFnCalled {
+		t.Fatal("update all should not be called")
+	}
+}
+
+func TestEditUnchanged(t *testing.T) {
+	e := newTestEditRunner()
+	if err := e.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if !e.editor.(*mocks.Editor).EditFnCalled ||
+		e.markService.(*mocks.MarkService).UpdateAllFnCalled {
+		t.Fatal("editor should be opened without updating")
+	}
+}
@@ -0,0 +1,61 @@
+package runner
+
+import (
+	"io"
+
+	"github.com/tomguerney/marks/marks"
+)
+
+type exportRunner struct {
+	args        *ExportArgs
+	config      *marks.Config
+	markService marks.MarkService
+	printer     marks.Printer
+	exporter    exporter
+	out         io.Writer
+}
+
+type ExportArgs struct {
+	id   string
+	url  string
+	tags []string
+}
+
+type exporter interface {
+	Export([]*marks.Mark) ([]byte, error)
+}
+
+func NewExportRunner(
+	args *ExportArgs,
+	config *marks.Config,
+	markService marks.MarkService,
+	printer marks.Printer,
+	exporter exporter,
+	out io.Writer,
+) *exportRunner {
+	return &exportRunner{
+		args,
+		config,
+		markService,
+		printer,
+		exporter,
+		out,
+	}
+}
+
+func NewExportArgs(id, url string, tags []string) *ExportArgs {
+	return &ExportArgs{id, url, tags}
+}
+
+func (e *exportRunner) Run() error {
+	filtered, err := e.markService.Filter(e.args.id, e.args.url, e.args.tags)
+	if err != nil {
+		return err
+	}
+	exported, err := e.exporter.Export(filtered)
+	if err != nil {
+		return err
+	}
+	_, err = e.out.Write(exported)
+	return err
+}
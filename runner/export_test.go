@@ -0,0 +1,61 @@
+package runner
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/tomguerney/marks/marks"
+	"github.com/tomguerney/marks/mocks"
+)
+
+func newTestExportRunner() *exportRunner {
+	return &exportRunner{
+		args:        &ExportArgs{},
+		config:      mocks.NewConfig(),
+		markService: mocks.NewMarkService(),
+		printer:     mocks.NewPrinter(),
+		exporter:    mocks.NewExporter(),
+		out:         mocks.NewWriter(),
+	}
+}
+
+func TestExportSuccess(t *testing.T) {
+	r := newTestExportRunner()
+	exportFn := func(actual []*marks.Mark) ([]byte, error) {
+		expected := []*marks.Mark{mocks.DefaultMarks[0], mocks.DefaultMarks[1]}
+		if !reflect.DeepEqual(actual, expected) {
+			t.Fatalf("expected %v, received %v", expected, actual)
+		}
+		return []byte("exported"), nil
+	}
+	writeFn := mocks.NewTestWriteFn(func(actual string) {
+		if actual != "exported" {
+			t.Fatalf("expected exported, received %v", actual)
+		}
+	})
+	r.exporter.(*mocks.Exporter).ExportFn = exportFn
+	r.out.(*mocks.Writer).WriteFn = writeFn
+	if err := r.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if !r.markService.(*mocks.MarkService).FilterFnCalled ||
+		!r.exporter.(*mocks.Exporter).ExportFnCalled ||
+		!r.out.(*mocks.Writer).WriteFnCalled {
+		t.Fatal("filter, export and write should all be called")
+	}
+}
+
+func TestExportExporterError(t *testing.T) {
+	r := newTestExportRunner()
+	exportFn := func([]*marks.Mark) ([]byte, error) {
+		return nil, errors.New("error")
+	}
+	r.exporter.(*mocks.Exporter).ExportFn = exportFn
+	if err := r.Run(); err == nil {
+		t.Fatal("should return error")
+	}
+	if r.out.(*mocks.Writer).WriteFnCalled {
+		t.Fatal("write should not be called")
+	}
+}
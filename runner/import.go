@@ -0,0 +1,118 @@
+package runner
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tomguerney/marks/marks"
+)
+
+type importRunner struct {
+	args        *ImportArgs
+	config      *marks.Config
+	markService marks.MarkService
+	printer     marks.Printer
+	reader      reader
+	importer    importer
+}
+
+type ImportArgs struct {
+	path   string
+	dryRun bool
+}
+
+type reader interface {
+	ReadFile(string) ([]byte, error)
+}
+
+type importer interface {
+	Import([]byte) ([]*marks.Mark, error)
+}
+
+func NewImportRunner(
+	args *ImportArgs,
+	config *marks.Config,
+	markService marks.MarkService,
+	printer marks.Printer,
+	reader reader,
+	importer importer,
+) *importRunner {
+	return &importRunner{
+		args,
+		config,
+		markService,
+		printer,
+		reader,
+		importer,
+	}
+}
+
+func NewImportArgs(path string, dryRun bool) *ImportArgs {
+	return &ImportArgs{path, dryRun}
+}
+
+func (i *importRunner) Run() error {
+	data, err := i.reader.ReadFile(i.args.path)
+	if err != nil {
+		return err
+	}
+	imported, err := i.importer.Import(data)
+	if err != nil {
+		return err
+	}
+	if len(imported) == 0 {
+		i.printer.Msg("No bookmarks found in %v", i.args.path)
+		return i.printer.Record("import")
+	}
+	existing, err := i.markService.Marks()
+	if err != nil {
+		return err
+	}
+	renamed := uniqueIds(existing, imported)
+	if i.args.dryRun {
+		return i.preview(imported, renamed)
+	}
+	if err := i.markService.CreateAll(imported); err != nil {
+		return err
+	}
+	i.printer.Msg("Imported %v bookmarks from %v", len(imported), i.args.path)
+	if renamed > 0 {
+		i.printer.Msg("%v bookmarks were renamed to avoid duplicate ids", renamed)
+	}
+	return i.printer.Record("import", imported...)
+}
+
+func (i *importRunner) preview(imported []*marks.Mark, renamed int) error {
+	table, err := i.printer.Tabulate(imported)
+	if err != nil {
+		return err
+	}
+	i.printer.Msg("Would import %v bookmarks from %v:", len(imported), i.args.path)
+	i.printer.Msg("%v", strings.Join(table, "\n"))
+	if renamed > 0 {
+		i.printer.Msg("%v bookmarks would be renamed to avoid duplicate ids", renamed)
+	}
+	return i.printer.Record("dry-run", imported...)
+}
+
+// uniqueIds renames any imported marks whose ids clash with an existing
+// mark, or with an earlier imported mark, by appending a counter, e.g.
+// "Google (2)". It returns the number of marks renamed.
+func uniqueIds(existing, imported []*marks.Mark) (renamed int) {
+	taken := map[string]bool{}
+	for _, m := range existing {
+		taken[strings.ToLower(m.Id)] = true
+	}
+	for _, m := range imported {
+		id := m.Id
+		for n := 2; taken[strings.ToLower(id)]; n++ {
+			id = fmt.Sprintf("%v (%v)", m.Id, n)
+		}
+		if id != m.Id {
+			m.Id = id
+			renamed++
+		}
+		taken[strings.ToLower(id)] = true
+	}
+	return renamed
+}
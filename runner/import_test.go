@@ -0,0 +1,123 @@
+package runner
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/tomguerney/marks/marks"
+	"github.com/tomguerney/marks/mocks"
+)
+
+func newTestImportRunner() *importRunner {
+	return &importRunner{
+		args:        &ImportArgs{path: "bookmarks.html"},
+		config:      mocks.NewConfig(),
+		markService: mocks.NewMarkService(),
+		printer:     mocks.NewPrinter(),
+		reader:      mocks.NewReader(),
+		importer:    mocks.NewImporter(),
+	}
+}
+
+func TestImportSuccess(t *testing.T) {
+	r := newTestImportRunner()
+	importFn := func([]byte) ([]*marks.Mark, error) {
+		return []*marks.Mark{
+			&marks.Mark{Id: "Google", Url: "https://www.google.com/"},
+			&marks.Mark{Id: "Github", Url: "https://github.com/"},
+			&marks.Mark{Id: "github", Url: "https://github.com/tomguerney"},
+		}, nil
+	}
+	createAllFn := func(mks []*marks.Mark) error {
+		expected := []string{"Google (2)", "Github", "github (2)"}
+		for i, m := range mks {
+			if m.Id != expected[i] {
+				t.Fatalf("expected %v, received %v", expected[i], m.Id)
+			}
+		}
+		return nil
+	}
+	r.importer.(*mocks.Importer).ImportFn = importFn
+	r.markService.(*mocks.MarkService).CreateAllFn = createAllFn
+	if err := r.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if !r.reader.(*mocks.Reader).ReadFileFnCalled ||
+		!r.markService.(*mocks.MarkService).CreateAllFnCalled ||
+		!r.printer.(*mocks.Printer).RecordFnCalled {
+		t.Fatal("read file, create all and record should all be called")
+	}
+	if r.markService.(*mocks.MarkService).CreateFnCalled {
+		t.Fatal("create should not be called")
+	}
+}
+
+func TestImportNoMarks(t *testing.T) {
+	r := newTestImportRunner()
+	importFn := func([]byte) ([]*marks.Mark, error) {
+		return []*marks.Mark{}, nil
+	}
+	r.importer.(*mocks.Importer).ImportFn = importFn
+	if err := r.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if r.markService.(*mocks.MarkService).CreateAllFnCalled {
+		t.Fatal("create all should not be called")
+	}
+}
+
+func TestImportReadError(t *testing.T) {
+	r := newTestImportRunner()
+	readFileFn := func(string) ([]byte, error) {
+		return nil, errors.New("error")
+	}
+	r.reader.(*mocks.Reader).ReadFileFn = readFileFn
+	if err := r.Run(); err == nil {
+		t.Fatal("should return error")
+	}
+	if r.importer.(*mocks.Importer).ImportFnCalled {
+		t.Fatal("import should not be called")
+	}
+}
+
+func TestImportImporterError(t *testing.T) {
+	r := newTestImportRunner()
+	importFn := func([]byte) ([]*marks.Mark, error) {
+		return nil, errors.New("error")
+	}
+	r.importer.(*mocks.Importer).ImportFn = importFn
+	if err := r.Run(); err == nil {
+		t.Fatal("should return error")
+	}
+	if r.markService.(*mocks.MarkService).CreateAllFnCalled {
+		t.Fatal("create all should not be called")
+	}
+}
+
+func TestImportCreateAllError(t *testing.T) {
+	r := newTestImportRunner()
+	createAllFn := func([]*marks.Mark) error {
+		return errors.New("error")
+	}
+	r.markService.(*mocks.MarkService).CreateAllFn = createAllFn
+	if err := r.Run(); err == nil {
+		t.Fatal("should return error")
+	}
+	if r.printer.(*mocks.Printer).RecordFnCalled {
+		t.Fatal("record should not be called")
+	}
+}
+
+func TestImportDryRun(t *testing.T) {
+	r := newTestImportRunner()
+	r.args.dryRun = true
+	if err := r.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if !r.printer.(*mocks.Printer).TabulateFnCalled {
+		t.Fatal("tabulate should be called")
+	}
+	if r.markService.(*mocks.MarkService).CreateAllFnCalled {
+		t.Fatal("create all should not be called")
+	}
+}
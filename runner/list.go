@@ -0,0 +1,200 @@
+package runner
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tomguerney/marks/marks"
+)
+
+type list struct {
+	args        *ListArgs
+	config      *marks.Config
+	markService marks.MarkService
+	printer     marks.Printer
+}
+
+type ListArgs struct {
+	id      string
+	url     string
+	tags    []string
+	sort    string
+	limit   int
+	offset  int
+	reverse bool
+	window  *TimeWindow
+	query   string
+}
+
+// TimeWindow restricts marks by when they were created and last opened.
+// Zero bounds are ignored.
+type TimeWindow struct {
+	since        time.Time
+	before       time.Time
+	openedSince  time.Time
+	openedBefore time.Time
+}
+
+func NewListRunner(
+	args *ListArgs,
+	config *marks.Config,
+	markService marks.MarkService,
+	printer marks.Printer,
+) *list {
+	return &list{
+		args,
+		config,
+		markService,
+		printer,
+	}
+}
+
+func NewListArgs(
+	id, url string,
+	tags []string,
+	sort string,
+	limit, offset int,
+	reverse bool,
+	window *TimeWindow,
+	query string,
+) *ListArgs {
+	return &ListArgs{
+		id:      id,
+		url:     url,
+		tags:    tags,
+		sort:    sort,
+		limit:   limit,
+		offset:  offset,
+		reverse: reverse,
+		window:  window,
+		query:   query,
+	}
+}
+
+func NewTimeWindow(since, before, openedSince, openedBefore time.Time) *TimeWindow {
+	return &TimeWindow{since, before, openedSince, openedBefore}
+}
+
+// contains reports whether the mark was created and last opened within the
+// window. Like the created: and opened: query terms, a bound never matches a
+// mark without that time recorded, so --opened-before leaves out marks that
+// have never been opened.
+func (w *TimeWindow) contains(m *marks.Mark) bool {
+	if w == nil {
+		return true
+	}
+	return between(m.CreatedAt(), w.since, w.before) &&
+		between(m.LastOpenedAt(), w.openedSince, w.openedBefore)
+}
+
+// between reports whether t is at or after since and before before,
+// ignoring zero bounds.
+func between(t, since, before time.Time) bool {
+	if since.IsZero() && before.IsZero() {
+		return true
+	}
+	if t.IsZero() {
+		return false
+	}
+	if !since.IsZero() && t.Before(since) {
+		return false
+	}
+	return before.IsZero() || t.Before(before)
+}
+
+func (l *list) Run() error {
+	expr, id := withSavedSearch(l.args.query, l.args.id)
+	filtered, err := search(l.markService, expr, id, l.args.url, l.args.tags)
+	if err != nil {
+		return err
+	}
+	filtered = filterByTime(filtered, l.args.window)
+	if err := sortMarks(filtered, l.args.sort); err != nil {
+		return err
+	}
+	if l.args.reverse {
+		reverseMarks(filtered)
+	}
+	page := paginate(filtered, l.args.offset, l.args.limit)
+	if len(page) == 0 {
+		l.printer.Msg("No bookmarks found")
+		return l.printer.Record("list")
+	}
+	table, err := l.printer.Tabulate(page)
+	if err != nil {
+		return err
+	}
+	l.printer.Msg("%v", strings.Join(table, "\n"))
+	return l.printer.Record("list", page...)
+}
+
+func sortMarks(mks []*marks.Mark, by string) error {
+	var less func(a, b *marks.Mark) bool
+	switch by {
+	case "":
+		return nil
+	case "id":
+		less = func(a, b *marks.Mark) bool {
+			return strings.ToLower(a.Id) < strings.ToLower(b.Id)
+		}
+	case "url":
+		less = func(a, b *marks.Mark) bool {
+			return strings.ToLower(a.Url) < strings.ToLower(b.Url)
+		}
+	case "tags":
+		less = func(a, b *marks.Mark) bool {
+			return strings.ToLower(strings.Join(a.Tags, ",")) < strings.ToLower(strings.Join(b.Tags, ","))
+		}
+	case "created":
+		less = func(a, b *marks.Mark) bool {
+			return a.CreatedAt().Before(b.CreatedAt())
+		}
+	case "updated":
+		less = func(a, b *marks.Mark) bool {
+			return a.UpdatedAt().Before(b.UpdatedAt())
+		}
+	case "opened":
+		less = func(a, b *marks.Mark) bool {
+			return a.LastOpenedAt().Before(b.LastOpenedAt())
+		}
+	default:
+		return errors.New(fmt.Sprintf("cannot sort by \"%v\"", by))
+	}
+	sort.SliceStable(mks, func(i, j int) bool {
+		return less(mks[i], mks[j])
+	})
+	return nil
+}
+
+func filterByTime(mks []*marks.Mark, window *TimeWindow) []*marks.Mark {
+	filtered := []*marks.Mark{}
+	for _, m := range mks {
+		if window.contains(m) {
+			filtered = append(filtered, m)
+		}
+	}
+	return filtered
+}
+
+func reverseMarks(mks []*marks.Mark) {
+	for i, j := 0, len(mks)-1; i < j; i, j = i+1, j-1 {
+		mks[i], mks[j] = mks[j], mks[i]
+	}
+}
+
+func paginate(mks []*marks.Mark, offset, limit int) []*marks.Mark {
+	if offset < 0 {
+		offset = 0
+	}
+	if offset >= len(mks) {
+		return []*marks.Mark{}
+	}
+	mks = mks[offset:]
+	if limit > 0 && limit < len(mks) {
+		mks = mks[:limit]
+	}
+	return mks
+}
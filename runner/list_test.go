@@ -0,0 +1,198 @@
+package runner
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/tomguerney/marks/marks"
+	"github.com/tomguerney/marks/mocks"
+)
+
+func newTestListRunner() *list {
+	return &list{
+		args:        &ListArgs{},
+		config:      mocks.NewConfig(),
+		markService: mocks.NewMarkService(),
+		printer:     mocks.NewPrinter(),
+	}
+}
+
+func TestListSuccess(t *testing.T) {
+	r := newTestListRunner()
+	var tabulated []*marks.Mark
+	tabulateFn := func(mks []*marks.Mark) ([]string, error) {
+		tabulated = mks
+		return []string{"row"}, nil
+	}
+	r.printer.(*mocks.Printer).TabulateFn = tabulateFn
+	if err := r.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+	expected := []*marks.Mark{mocks.DefaultMarks[0], mocks.DefaultMarks[1]}
+	if !reflect.DeepEqual(tabulated, expected) {
+		t.Fatalf("expected %v, received %v", expected, tabulated)
+	}
+	if !r.markService.(*mocks.MarkService).FilterFnCalled ||
+		!r.printer.(*mocks.Printer).MsgFnCalled {
+		t.Fatal("filter and msg should be called")
+	}
+}
+
+func TestListNoMarks(t *testing.T) {
+	r := newTestListRunner()
+	msgFn := func(actual string, i ...interface{}) {
+		expected := "No bookmarks found"
+		if actual != expected {
+			t.Fatalf("expected %v, received %v", expected, actual)
+		}
+	}
+	filterFn := func(string, string, []string) ([]*marks.Mark, error) {
+		return []*marks.Mark{}, nil
+	}
+	r.printer.(*mocks.Printer).MsgFn = msgFn
+	r.markService.(*mocks.MarkService).FilterFn = filterFn
+	if err := r.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if r.printer.(*mocks.Printer).TabulateFnCalled {
+		t.Fatal("tabulate should not be called")
+	}
+}
+
+func TestListFilterError(t *testing.T) {
+	r := newTestListRunner()
+	filterFn := func(string, string, []string) ([]*marks.Mark, error) {
+		return nil, errors.New("error")
+	}
+	r.markService.(*mocks.MarkService).FilterFn = filterFn
+	if err := r.Run(); err == nil {
+		t.Fatal("should return error")
+	}
+}
+
+func TestListSortReversePaginate(t *testing.T) {
+	r := newTestListRunner()
+	r.args = &ListArgs{sort: "id", reverse: true, offset: 1, limit: 1}
+	filterFn := func(string, string, []string) ([]*marks.Mark, error) {
+		return []*marks.Mark{mocks.DefaultMarks[0], mocks.DefaultMarks[1], mocks.DefaultMarks[2]}, nil
+	}
+	var tabulated []*marks.Mark
+	tabulateFn := func(mks []*marks.Mark) ([]string, error) {
+		tabulated = mks
+		return []string{}, nil
+	}
+	r.markService.(*mocks.MarkService).FilterFn = filterFn
+	r.printer.(*mocks.Printer).TabulateFn = tabulateFn
+	if err := r.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+	expected := []*marks.Mark{mocks.DefaultMarks[2]}
+	if !reflect.DeepEqual(tabulated, expected) {
+		t.Fatalf("expected %v, received %v", expected, tabulated)
+	}
+}
+
+func TestListUnsupportedSort(t *testing.T) {
+	r := newTestListRunner()
+	r.args.sort = "not a field"
+	if err := r.Run(); err == nil {
+		t.Fatal("should return error")
+	}
+}
+
+func TestSortMarksByUrl(t *testing.T) {
+	mks := []*marks.Mark{mocks.DefaultMarks[1], mocks.DefaultMarks[0], mocks.DefaultMarks[2]}
+	if err := sortMarks(mks, "url"); err != nil {
+		t.Fatal(err.Error())
+	}
+	expected := []*marks.Mark{mocks.DefaultMarks[0], mocks.DefaultMarks[2], mocks.DefaultMarks[1]}
+	if !reflect.DeepEqual(mks, expected) {
+		t.Fatalf("expected %v, received %v", expected, mks)
+	}
+}
+
+func TestPaginateOffsetOutOfRange(t *testing.T) {
+	actual := paginate(mocks.DefaultMarks, 10, 0)
+	if len(actual) != 0 {
+		t.Fatalf("expected zero length slice, received %v", len(actual))
+	}
+}
+
+func TestListRecordsPage(t *testing.T) {
+	r := newTestListRunner()
+	recordFn := func(action string, mks ...*marks.Mark) error {
+		if action != "list" {
+			t.Fatalf("expected list, received %v", action)
+		}
+		expected := []*marks.Mark{mocks.DefaultMarks[0], mocks.DefaultMarks[1]}
+		if !reflect.DeepEqual(mks, expected) {
+			t.Fatalf("expected %v, received %v", expected, mks)
+		}
+		return nil
+	}
+	r.printer.(*mocks.Printer).RecordFn = recordFn
+	if err := r.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if !r.printer.(*mocks.Printer).RecordFnCalled {
+		t.Fatal("record should be called")
+	}
+}
+
+func TestListTimeWindow(t *testing.T) {
+	r := newTestListRunner()
+	old := &marks.Mark{Id: "old", Created: marks.Timestamp(testNow.AddDate(0, 0, -60)), LastOpened: marks.Timestamp(testNow.AddDate(0, 0, -10))}
+	recent := &marks.Mark{Id: "recent", Created: marks.Timestamp(testNow.AddDate(0, 0, -5)), LastOpened: marks.Timestamp(testNow)}
+	unopened := &marks.Mark{Id: "unopened"}
+	r.markService.(*mocks.MarkService).FilterFn = func(string, string, []string) ([]*marks.Mark, error) {
+		return []*marks.Mark{old, recent, unopened}, nil
+	}
+	var tabulated []*marks.Mark
+	r.printer.(*mocks.Printer).TabulateFn = func(mks []*marks.Mark) ([]string, error) {
+		tabulated = mks
+		return []string{"row"}, nil
+	}
+	r.args = &ListArgs{window: NewTimeWindow(testNow.AddDate(0, 0, -30), time.Time{}, time.Time{}, time.Time{})}
+	if err := r.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+	expected := []*marks.Mark{recent}
+	if !reflect.DeepEqual(tabulated, expected) {
+		t.Fatalf("expected %v, received %v", expected, tabulated)
+	}
+	r.args = &ListArgs{window: NewTimeWindow(time.Time{}, time.Time{}, time.Time{}, testNow.AddDate(0, 0, -1))}
+	if err := r.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+	expected = []*marks.Mark{old}
+	if !reflect.DeepEqual(tabulated, expected) {
+		t.Fatalf("expected %v, received %v", expected, tabulated)
+	}
+}
+
+func TestSortMarksByCreated(t *testing.T) {
+	newer := &marks.Mark{Id: "newer", Created: marks.Timestamp(testNow)}
+	older := &marks.Mark{Id: "older", Created: marks.Timestamp(testNow.AddDate(-1, 0, 0))}
+	mks := []*marks.Mark{newer, older}
+	if err := sortMarks(mks, "created"); err != nil {
+		t.Fatal(err.Error())
+	}
+	expected := []*marks.Mark{older, newer}
+	if !reflect.DeepEqual(mks, expected) {
+		t.Fatalf("expected %v, received %v", expected, mks)
+	}
+}
+
+func TestListWithQuery(t *testing.T) {
+	r := newTestListRunner()
+	r.args = &ListArgs{query: "tag:news AND NOT tag:uk"}
+	if err := r.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if !r.markService.(*mocks.MarkService).SearchFnCalled ||
+		r.markService.(*mocks.MarkService).FilterFnCalled {
+		t.Fatal("search should be called instead of filter")
+	}
+}
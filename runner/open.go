@@ -1,6 +1,9 @@
 package runner
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/tomguerney/marks/marks"
 )
 
@@ -11,13 +14,19 @@ type open struct {
 }
 
 type OpenArgs struct {
-	id   string
-	url  string
-	tags []string
+	id      string
+	url     string
+	tags    []string
+	words   []string
+	query   string
+	browser string
+	explain bool
+	all     bool
+	multi   bool
 }
 
 type opener interface {
-	Open(url, browser string) error
+	Open(url, browser, profile string) error
 }
 
 func NewOpenRunner(
@@ -35,39 +44,202 @@ func NewOpenRunner(
 	}
 }
 
-func NewOpenArgs(id, url string, tags []string) *OpenArgs {
-	return &OpenArgs{id, url, tags}
+// NewOpenArgs builds the args for open. Words are the arguments after the id:
+// they fill in the url of a keyword bookmark, whose id is given exactly and
+// whose url is a template, and are otherwise matched as tags. A browser given
+// here overrides the bookmark's own browser and any routes. With all set
+// every match is opened, and with multi set the matches to open are picked
+// from a list.
+func NewOpenArgs(id, url string, tags, words []string, query, browser string, explain, all, multi bool) *OpenArgs {
+	return &OpenArgs{id, url, tags, words, query, browser, explain, all, multi}
 }
 
 func (o *open) Run() error {
 
-	selected, err := o.filter("Select bookmark to open", o.args.id, o.args.url, o.args.tags)
+	selected, params, err := o.selectMarks()
+
+	if rerr, ok := err.(*runnerError); ok {
+		o.printer.Error(rerr.Error())
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return o.launch(selected, params)
+}
 
+// selectMarks returns the marks to open and, for a keyword bookmark, the
+// parameters to fill in its url with.
+func (o *open) selectMarks() ([]*marks.Mark, []string, error) {
+	keyword, err := o.keyword()
 	if err != nil {
-		if err, ok := err.(*runnerError); ok {
-			o.printer.Error(err.Error())
-			return nil
-		} else {
+		return nil, nil, err
+	}
+	if keyword != nil {
+		return []*marks.Mark{keyword}, o.args.words, nil
+	}
+	tags := append(append([]string{}, o.args.tags...), o.args.words...)
+	if o.args.all || o.args.multi {
+		selected, err := o.filterMany("Select bookmarks to open", o.args.query, o.args.id, o.args.url, tags, o.args.all)
+		return selected, nil, o.notKeyword(err)
+	}
+	selected, err := o.filter("Select bookmark to open", o.args.query, o.args.id, o.args.url, tags)
+	if err != nil {
+		return nil, nil, o.notKeyword(err)
+	}
+	return []*marks.Mark{selected}, nil, nil
+}
+
+// notKeyword explains, when nothing matched the id and words, that the
+// words were matched as tags because the id is not a keyword bookmark, as
+// it would be if the keyword were mistyped.
+func (o *open) notKeyword(err error) error {
+	rerr, ok := err.(*runnerError)
+	if !ok || len(o.args.words) == 0 {
+		return err
+	}
+	return &runnerError{fmt.Sprintf("No keyword bookmark \"%v\", so %v were matched as tags\n%v", o.args.id, strings.Join(o.args.words, " "), rerr.Error())}
+}
+
+// keyword returns the mark with exactly the given id if its url is a
+// template, as in "marks open jira PROJ-123".
+func (o *open) keyword() (*marks.Mark, error) {
+	if o.args.id == "" || o.args.query != "" || o.args.all || o.args.multi {
+		return nil, nil
+	}
+	all, err := o.markService.Marks()
+	if err != nil {
+		return nil, err
+	}
+	m := findMark(all, o.args.id)
+	if m == nil || !marks.IsUrlTemplate(m.Url) {
+		return nil, nil
+	}
+	return m, nil
+}
+
+// launch opens the marks in order, asking first if there are more of them
+// than the configured threshold. Params fill in any url templates.
+func (o *open) launch(mks []*marks.Mark, params []string) error {
+
+	if len(mks) == 0 {
+		o.printer.Msg("No bookmarks selected")
+		return o.printer.Record("open")
+	}
+
+	if o.args.explain {
+		for _, m := range mks {
+			if err := o.explain(m, params); err != nil {
+				return err
+			}
+		}
+		return o.printer.Record("open explain", mks...)
+	}
+
+	if o.config.OpenThreshold > 0 && len(mks) > o.config.OpenThreshold &&
+		!o.prompter.Confirm(fmt.Sprintf("Open %v bookmarks", len(mks))) {
+		o.printer.Msg("Exiting")
+		return nil
+	}
+
+	for _, m := range mks {
+		if err := o.open(m, params); err != nil {
 			return err
 		}
 	}
 
-	err = o.opener.Open(selected.Url, o.config.Browser)
+	return o.printer.Record("open", mks...)
+}
+
+func (o *open) open(m *marks.Mark, params []string) error {
+
+	expanded, err := o.expand(m, params)
 	if err != nil {
 		return err
 	}
 
-	printUrl, err := o.printer.Url(selected.Url)
+	routing := o.route(expanded)
+
+	printUrl, printBrowser, err := o.describe(expanded.Url, routing)
 	if err != nil {
 		return err
 	}
 
-	printBrowser, err := o.printer.Browser(o.config.Browser)
+	err = o.opener.Open(expanded.Url, routing.Browser, routing.Profile)
 	if err != nil {
 		return err
 	}
 
+	if err := o.touch(m); err != nil {
+		return err
+	}
+
 	o.printer.Msg("Url opened in %v: %v", printBrowser, printUrl)
 
 	return nil
 }
+
+func (o *open) explain(m *marks.Mark, params []string) error {
+
+	expanded, err := o.expand(m, params)
+	if err != nil {
+		return err
+	}
+
+	routing := o.route(expanded)
+
+	printUrl, printBrowser, err := o.describe(expanded.Url, routing)
+	if err != nil {
+		return err
+	}
+
+	o.printer.Msg("Url would open in %v: %v", printBrowser, printUrl)
+	o.printer.Msg("Because %v", routing.Reason)
+
+	return nil
+}
+
+// expand returns a copy of the mark with its url template filled in from
+// params, prompting for any that are missing, so that it is routed by the
+// url that is actually opened.
+func (o *open) expand(m *marks.Mark, params []string) (*marks.Mark, error) {
+	url, err := marks.ExpandUrl(m.Url, params, func(label string) (string, error) {
+		return o.prompter.Input(fmt.Sprintf("%v for %v", label, m.Id))
+	})
+	if err != nil {
+		return nil, err
+	}
+	expanded := m.Copy()
+	expanded.Url = url
+	return expanded, nil
+}
+
+// describe returns the printed url and browser, with the profile if the
+// routing sets one.
+func (o *open) describe(url string, routing *marks.Routing) (string, string, error) {
+
+	printUrl, err := o.printer.Url(url)
+	if err != nil {
+		return "", "", err
+	}
+
+	printBrowser, err := o.printer.Browser(routing.Browser)
+	if err != nil {
+		return "", "", err
+	}
+
+	if routing.Profile != "" {
+		printBrowser = fmt.Sprintf("%v (%v)", printBrowser, routing.Profile)
+	}
+
+	return printUrl, printBrowser, nil
+}
+
+func (o *open) route(m *marks.Mark) *marks.Routing {
+	if o.args.browser != "" {
+		return &marks.Routing{Browser: o.args.browser, Reason: "--browser was given"}
+	}
+	return marks.RouteMark(m, o.config)
+}
@@ -2,6 +2,7 @@ package runner
 
 import (
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/tomguerney/marks/marks"
@@ -30,7 +31,7 @@ func TestOpenSuccess(t *testing.T) {
 	filterFn := func(string, string, []string) ([]*marks.Mark, error) {
 		return []*marks.Mark{m}, nil
 	}
-	openFn := func(actualUrl, actualBrowser string) error {
+	openFn := func(actualUrl, actualBrowser, actualProfile string) error {
 		if actualUrl != m.Url {
 			t.Fatalf("expected %v, received %v", m.Url, actualUrl)
 		}
@@ -42,9 +43,24 @@ func TestOpenSuccess(t *testing.T) {
 	r.printer.(*mocks.Printer).MsgFn = msgFn
 	r.markService.(*mocks.MarkService).FilterFn = filterFn
 	r.opener.(*mocks.Opener).OpenFn = openFn
+	r.markService.(*mocks.MarkService).UpdateFn = func(id string, actual *marks.Mark) error {
+		if id != m.Id {
+			t.Fatalf("expected %v, received %v", m.Id, id)
+		}
+		if !actual.LastOpened.Equal(testNow) {
+			t.Fatalf("expected %v, received %v", testNow, actual.LastOpened)
+		}
+		if actual.Visits != m.Visits+1 {
+			t.Fatalf("expected %v visits, received %v", m.Visits+1, actual.Visits)
+		}
+		return nil
+	}
 	if err := r.Run(); err != nil {
 		t.Fatal(err.Error())
 	}
+	if !r.markService.(*mocks.MarkService).UpdateFnCalled {
+		t.Fatal("update should be called to record last opened")
+	}
 	if !r.printer.(*mocks.Printer).MsgFnCalled ||
 		!r.markService.(*mocks.MarkService).FilterFnCalled ||
 		!r.opener.(*mocks.Opener).OpenFnCalled {
@@ -93,7 +109,7 @@ func TestOpenOpenError(t *testing.T) {
 	filterFn := func(string, string, []string) ([]*marks.Mark, error) {
 		return []*marks.Mark{m}, nil
 	}
-	openFn := func(string, string) error {
+	openFn := func(string, string, string) error {
 		return errors.New("error")
 	}
 	r.markService.(*mocks.MarkService).FilterFn = filterFn
@@ -102,3 +118,268 @@ func TestOpenOpenError(t *testing.T) {
 		t.Fatal("should return error")
 	}
 }
+
+func TestOpenRoutesMark(t *testing.T) {
+	m := &marks.Mark{Id: "Jira", Url: "https://corp.atlassian.net", Tags: []string{"work/tickets"}}
+	r := newTestOpenRunner()
+	r.config.Browser = "firefox"
+	r.config.TagSeparator = "/"
+	r.config.Routes = []*marks.Route{
+		&marks.Route{Tag: "work", Browser: "chrome", Profile: "Work"},
+	}
+	r.markService.(*mocks.MarkService).FilterFn = func(string, string, []string) ([]*marks.Mark, error) {
+		return []*marks.Mark{m}, nil
+	}
+	r.opener.(*mocks.Opener).OpenFn = func(url, browser, profile string) error {
+		if browser != "chrome" || profile != "Work" {
+			t.Fatalf("expected chrome with the Work profile, received %v %v", browser, profile)
+		}
+		return nil
+	}
+	if err := r.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if !r.opener.(*mocks.Opener).OpenFnCalled {
+		t.Fatal("open should be called")
+	}
+}
+
+func TestOpenBrowserFlagOverridesRoutes(t *testing.T) {
+	m := &marks.Mark{Id: "Jira", Url: "https://corp.atlassian.net", Browser: "chrome"}
+	r := newTestOpenRunner()
+	r.args.browser = "lynx"
+	r.markService.(*mocks.MarkService).FilterFn = func(string, string, []string) ([]*marks.Mark, error) {
+		return []*marks.Mark{m}, nil
+	}
+	r.opener.(*mocks.Opener).OpenFn = func(url, browser, profile string) error {
+		if browser != "lynx" || profile != "" {
+			t.Fatalf("expected lynx, received %v %v", browser, profile)
+		}
+		return nil
+	}
+	if err := r.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+}
+
+func TestOpenExplain(t *testing.T) {
+	m := &marks.Mark{Id: "Jira", Url: "https://corp.atlassian.net"}
+	r := newTestOpenRunner()
+	r.args.explain = true
+	r.config.Routes = []*marks.Route{
+		&marks.Route{Host: "*.atlassian.net", Browser: "chrome"},
+	}
+	r.markService.(*mocks.MarkService).FilterFn = func(string, string, []string) ([]*marks.Mark, error) {
+		return []*marks.Mark{m}, nil
+	}
+	var reason interface{}
+	r.printer.(*mocks.Printer).MsgFn = func(msg string, i ...interface{}) {
+		if msg == "Because %v" {
+			reason = i[0].([]interface{})[0]
+		}
+	}
+	if err := r.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if reason != "route 1 (host:*.atlassian.net) matched" {
+		t.Fatalf("unexpected reason %v", reason)
+	}
+	if r.opener.(*mocks.Opener).OpenFnCalled ||
+		r.markService.(*mocks.MarkService).UpdateFnCalled {
+		t.Fatal("explain should not open or touch the bookmark")
+	}
+}
+
+func TestOpenAll(t *testing.T) {
+	r := newTestOpenRunner()
+	r.args.all = true
+	r.config.OpenThreshold = 5
+	var opened int
+	r.opener.(*mocks.Opener).OpenFn = func(string, string, string) error {
+		opened++
+		return nil
+	}
+	if err := r.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if opened != 2 {
+		t.Fatalf("expected 2 opened, received %v", opened)
+	}
+	if r.prompter.(*mocks.Prompter).ConfirmFnCalled ||
+		r.prompter.(*mocks.Prompter).MultiSelectFnCalled {
+		t.Fatal("should open without prompting")
+	}
+}
+
+func TestOpenMulti(t *testing.T) {
+	r := newTestOpenRunner()
+	r.args.multi = true
+	r.prompter.(*mocks.Prompter).MultiSelectFn = func(string, []string) ([]int, error) {
+		return []int{1}, nil
+	}
+	var opened []string
+	r.opener.(*mocks.Opener).OpenFn = func(url, browser, profile string) error {
+		opened = append(opened, url)
+		return nil
+	}
+	if err := r.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(opened) != 1 || opened[0] != mocks.DefaultMarks[1].Url {
+		t.Fatalf("expected %v opened, received %v", mocks.DefaultMarks[1].Url, opened)
+	}
+}
+
+func TestOpenKeyword(t *testing.T) {
+	m := &marks.Mark{Id: "jira", Url: "https://jira.example.com/browse/{{.Args 0}}?q={{.Query | queryescape}}"}
+	r := newTestOpenRunner()
+	r.args.id = "Jira"
+	r.args.words = []string{"PROJ-123", "fix"}
+	r.markService.(*mocks.MarkService).MarksFn = func() ([]*marks.Mark, error) {
+		return []*marks.Mark{m}, nil
+	}
+	var opened string
+	r.opener.(*mocks.Opener).OpenFn = func(url, browser, profile string) error {
+		opened = url
+		return nil
+	}
+	if err := r.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+	expected := "https://jira.example.com/browse/PROJ-123?q=PROJ-123+fix"
+	if opened != expected {
+		t.Fatalf("expected %v, received %v", expected, opened)
+	}
+	if r.markService.(*mocks.MarkService).FilterFnCalled ||
+		r.prompter.(*mocks.Prompter).InputFnCalled {
+		t.Fatal("keyword should open without filtering or prompting")
+	}
+}
+
+func TestOpenKeywordRoutesExpandedUrl(t *testing.T) {
+	m := &marks.Mark{Id: "jira", Url: "https://{{.Args 0}}/browse/{{.Args 1}}"}
+	r := newTestOpenRunner()
+	r.args.id = "jira"
+	r.args.words = []string{"corp.atlassian.net", "PROJ-7"}
+	r.config.Browser = "firefox"
+	r.config.Routes = []*marks.Route{
+		&marks.Route{Host: "*.atlassian.net", Browser: "chrome"},
+	}
+	r.markService.(*mocks.MarkService).MarksFn = func() ([]*marks.Mark, error) {
+		return []*marks.Mark{m}, nil
+	}
+	r.opener.(*mocks.Opener).OpenFn = func(url, browser, profile string) error {
+		if url != "https://corp.atlassian.net/browse/PROJ-7" || browser != "chrome" {
+			t.Fatalf("expected the expanded url in chrome, received %v in %v", url, browser)
+		}
+		return nil
+	}
+	if err := r.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if !r.opener.(*mocks.Opener).OpenFnCalled {
+		t.Fatal("open should be called")
+	}
+}
+
+func TestOpenKeywordExplain(t *testing.T) {
+	m := &marks.Mark{Id: "jira", Url: "https://{{.Args 0}}/browse/{{.Args 1}}"}
+	r := newTestOpenRunner()
+	r.args.id = "jira"
+	r.args.words = []string{"corp.atlassian.net", "PROJ-7"}
+	r.args.explain = true
+	r.config.Routes = []*marks.Route{
+		&marks.Route{Host: "*.atlassian.net", Browser: "chrome"},
+	}
+	r.markService.(*mocks.MarkService).MarksFn = func() ([]*marks.Mark, error) {
+		return []*marks.Mark{m}, nil
+	}
+	var url, reason interface{}
+	r.printer.(*mocks.Printer).MsgFn = func(msg string, i ...interface{}) {
+		switch msg {
+		case "Url would open in %v: %v":
+			url = i[0].([]interface{})[1]
+		case "Because %v":
+			reason = i[0].([]interface{})[0]
+		}
+	}
+	if err := r.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if url != "https://corp.atlassian.net/browse/PROJ-7" {
+		t.Fatalf("expected the expanded url, received %v", url)
+	}
+	if reason != "route 1 (host:*.atlassian.net) matched" {
+		t.Fatalf("unexpected reason %v", reason)
+	}
+	if r.opener.(*mocks.Opener).OpenFnCalled {
+		t.Fatal("explain should not open the bookmark")
+	}
+}
+
+func TestOpenMistypedKeyword(t *testing.T) {
+	r := newTestOpenRunner()
+	r.args.id = "jria"
+	r.args.words = []string{"PROJ-7"}
+	r.markService.(*mocks.MarkService).FilterFn = func(string, string, []string) ([]*marks.Mark, error) {
+		return []*marks.Mark{}, nil
+	}
+	var msg string
+	r.printer.(*mocks.Printer).ErrorFn = func(actual string, i ...interface{}) {
+		msg = actual
+	}
+	if err := r.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if !strings.HasPrefix(msg, "No keyword bookmark \"jria\", so PROJ-7 were matched as tags\n") {
+		t.Fatalf("unexpected error %q", msg)
+	}
+	if r.opener.(*mocks.Opener).OpenFnCalled {
+		t.Fatal("nothing should be opened")
+	}
+}
+
+func TestOpenKeywordPromptsForMissingArgs(t *testing.T) {
+	m := &marks.Mark{Id: "jira", Url: "https://jira.example.com/browse/{{.Args 0}}"}
+	r := newTestOpenRunner()
+	r.args.id = "jira"
+	r.markService.(*mocks.MarkService).MarksFn = func() ([]*marks.Mark, error) {
+		return []*marks.Mark{m}, nil
+	}
+	r.prompter.(*mocks.Prompter).InputFn = func(label string) (string, error) {
+		if label != "Argument 0 for jira" {
+			t.Fatalf("unexpected label %v", label)
+		}
+		return "PROJ-7", nil
+	}
+	var opened string
+	r.opener.(*mocks.Opener).OpenFn = func(url, browser, profile string) error {
+		opened = url
+		return nil
+	}
+	if err := r.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if opened != "https://jira.example.com/browse/PROJ-7" {
+		t.Fatalf("unexpected url %v", opened)
+	}
+}
+
+func TestOpenWordsMatchTagsWithoutKeyword(t *testing.T) {
+	r := newTestOpenRunner()
+	r.args.id = "Google"
+	r.args.tags = []string{"search"}
+	r.args.words = []string{"web"}
+	r.markService.(*mocks.MarkService).FilterFn = func(id, url string, tags []string) ([]*marks.Mark, error) {
+		if len(tags) != 2 || tags[0] != "search" || tags[1] != "web" {
+			t.Fatalf("expected words to be matched as tags, received %v", tags)
+		}
+		return []*marks.Mark{mocks.DefaultMarks[1]}, nil
+	}
+	if err := r.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if r.prompter.(*mocks.Prompter).InputFnCalled {
+		t.Fatal("input should not be prompted for")
+	}
+}
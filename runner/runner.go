@@ -3,10 +3,17 @@ package runner
 import (
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
+	"github.com/tomguerney/marks/fuzzy"
 	"github.com/tomguerney/marks/marks"
+	"github.com/tomguerney/marks/query"
 )
 
+// now is swapped out in tests for a fixed clock.
+var now = time.Now
+
 type runner struct {
 	config      *marks.Config
 	markService marks.MarkService
@@ -23,30 +30,21 @@ func newRunner(config *marks.Config, markService marks.MarkService, printer mark
 	}
 }
 
-func (r *runner) filter(prompt, id, url string, tags []string) (*marks.Mark, error) {
+func (r *runner) filter(prompt, expr, id, url string, tags []string) (*marks.Mark, error) {
 
-	filtered, err := r.markService.Filter(id, url, tags)
+	filtered, err := r.matches(expr, id, url, tags)
 	if err != nil {
 		return nil, err
 	}
 
-	if len(filtered) == 0 {
-
-		filterMark := &marks.Mark{Id: id, Url: url, Tags: tags}
-
-		printFilterMark, err := r.printer.FullMarkWithFields(filterMark)
-		if err != nil {
-			return nil, err
-		}
-
-		return nil, &runnerError{fmt.Sprintf("No bookmarks found matching: %v", printFilterMark)}
-	}
-
 	var i int
 
 	if len(filtered) == 1 {
 		i = 0
 	} else {
+		if err := marks.Rank(filtered, r.config.Rank, now()); err != nil {
+			return nil, err
+		}
 		table, err := r.printer.Tabulate(filtered)
 		if err != nil {
 			return nil, err
@@ -63,3 +61,127 @@ func (r *runner) filter(prompt, id, url string, tags []string) (*marks.Mark, err
 
 	return filtered[i], nil
 }
+
+// filterMany is filter for several marks. It returns every match if all is
+// set, otherwise the matches picked from a multi-select prompt.
+func (r *runner) filterMany(prompt, expr, id, url string, tags []string, all bool) ([]*marks.Mark, error) {
+
+	filtered, err := r.matches(expr, id, url, tags)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(filtered) == 1 {
+		return filtered, nil
+	}
+
+	if err := marks.Rank(filtered, r.config.Rank, now()); err != nil {
+		return nil, err
+	}
+
+	if all {
+		return filtered, nil
+	}
+
+	table, err := r.printer.Tabulate(filtered)
+	if err != nil {
+		return nil, err
+	}
+
+	indexes, err := r.prompter.MultiSelect(prompt, table)
+	if err != nil {
+		return nil, err
+	}
+
+	picked := []*marks.Mark{}
+	for _, i := range indexes {
+		if i >= len(filtered) {
+			return nil, errors.New(fmt.Sprintf("no mark at index %v", i))
+		}
+		picked = append(picked, filtered[i])
+	}
+
+	return picked, nil
+}
+
+// matches returns the marks matching the search, or a runnerError
+// describing the search and any near misses if there are none.
+func (r *runner) matches(expr, id, url string, tags []string) ([]*marks.Mark, error) {
+
+	expr, id = withSavedSearch(expr, id)
+
+	filtered, err := search(r.markService, expr, id, url, tags)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(filtered) == 0 {
+
+		if expr != "" {
+			combined := query.Combine(expr, id, url, tags)
+			return nil, &runnerError{fmt.Sprintf("No bookmarks found matching: %v", combined)}
+		}
+
+		filterMark := &marks.Mark{Id: id, Url: url, Tags: tags}
+
+		printFilterMark, err := r.printer.FullMarkWithFields(filterMark)
+		if err != nil {
+			return nil, err
+		}
+
+		msg := fmt.Sprintf("No bookmarks found matching: %v", printFilterMark)
+
+		suggestions, err := r.suggest(id, url, tags)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(suggestions) > 0 {
+			msg = fmt.Sprintf("%v\nDid you mean:\n%v", msg, strings.Join(suggestions, "\n"))
+		}
+
+		return nil, &runnerError{msg}
+	}
+
+	return filtered, nil
+}
+
+// search returns the marks matching the query expression, if there is
+// one, and the partial id, partial url and tags.
+func search(markService marks.MarkService, expr, id, url string, tags []string) ([]*marks.Mark, error) {
+	if expr == "" {
+		return markService.Filter(id, url, tags)
+	}
+	return markService.Search(query.Combine(expr, id, url, tags))
+}
+
+// maxSuggestions caps the "did you mean" list shown when nothing matches.
+const maxSuggestions = 3
+
+// suggest returns tabulated marks that fuzzily match the search, for when
+// an exact search finds nothing.
+func (r *runner) suggest(id, url string, tags []string) ([]string, error) {
+	if r.config.Fuzzy {
+		return nil, nil
+	}
+	all, err := r.markService.Marks()
+	if err != nil {
+		return nil, err
+	}
+	suggestions := fuzzy.Filter(all, id, url, tags, r.config.TagSeparator, marks.NewTagNormalizer(r.config))
+	if len(suggestions) == 0 {
+		return nil, nil
+	}
+	if len(suggestions) > maxSuggestions {
+		suggestions = suggestions[:maxSuggestions]
+	}
+	return r.printer.Tabulate(suggestions)
+}
+
+// touch records that the mark has just been opened or copied.
+func (r *runner) touch(m *marks.Mark) error {
+	touched := m.Copy()
+	touched.LastOpened = marks.Timestamp(now())
+	touched.Visits++
+	return r.markService.Update(m.Id, touched)
+}
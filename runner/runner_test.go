@@ -2,12 +2,20 @@ package runner
 
 import (
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/tomguerney/marks/marks"
 	"github.com/tomguerney/marks/mocks"
 )
 
+var testNow = time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+func init() {
+	now = func() time.Time { return testNow }
+}
+
 func newTestRunner() *runner {
 	return &runner{
 		config:      mocks.NewConfig(),
@@ -18,16 +26,16 @@ func newTestRunner() *runner {
 }
 
 type mockRunner struct {
-	filterFn       func(prompt, id, url string, tags []string) (*marks.Mark, error)
+	filterFn       func(prompt, expr, id, url string, tags []string) (*marks.Mark, error)
 	filterFnCalled bool
 }
 
-func (r *mockRunner) filter(prompt, id, url string, tags []string) (*marks.Mark, error) {
+func (r *mockRunner) filter(prompt, expr, id, url string, tags []string) (*marks.Mark, error) {
 	r.filterFnCalled = true
-	return r.filterFn(prompt, id, url, tags)
+	return r.filterFn(prompt, expr, id, url, tags)
 }
 
-var defaultFilterFn = func(prompt, id, url string, tags []string) (*marks.Mark, error) {
+var defaultFilterFn = func(prompt, expr, id, url string, tags []string) (*marks.Mark, error) {
 	return mocks.DefaultMarks[0], nil
 }
 
@@ -43,7 +51,7 @@ func TestFilterZeroMarks(t *testing.T) {
 		return []*marks.Mark{}, nil
 	}
 	r.markService.(*mocks.MarkService).FilterFn = filterFn
-	mark, err := r.filter("prompt", "id", "url", []string{"tag"})
+	mark, err := r.filter("prompt", "", "id", "url", []string{"tag"})
 	if mark != nil {
 		t.Fatal("expected nil mark")
 	}
@@ -52,6 +60,29 @@ func TestFilterZeroMarks(t *testing.T) {
 	}
 }
 
+func TestFilterZeroMarksSuggestsFuzzyMatches(t *testing.T) {
+	r := newTestRunner()
+	r.markService.(*mocks.MarkService).FilterFn = func(string, string, []string) ([]*marks.Mark, error) {
+		return []*marks.Mark{}, nil
+	}
+	var suggested []*marks.Mark
+	r.printer.(*mocks.Printer).TabulateFn = func(mks []*marks.Mark) ([]string, error) {
+		suggested = mks
+		return []string{"Google row"}, nil
+	}
+	_, err := r.filter("prompt", "", "gogle", "", []string{})
+	if _, ok := err.(*runnerError); !ok {
+		t.Fatal("expected runnerError")
+	}
+	expected := []*marks.Mark{mocks.DefaultMarks[1]}
+	if !reflect.DeepEqual(suggested, expected) {
+		t.Fatalf("expected %v, received %v", expected, suggested)
+	}
+	if !strings.HasSuffix(err.Error(), "Did you mean:\nGoogle row") {
+		t.Fatalf("expected suggestions in %v", err.Error())
+	}
+}
+
 func TestFilterOneMark(t *testing.T) {
 	expected := mocks.DefaultMarks[0]
 	r := newTestRunner()
@@ -59,7 +90,7 @@ func TestFilterOneMark(t *testing.T) {
 		return []*marks.Mark{expected}, nil
 	}
 	r.markService.(*mocks.MarkService).FilterFn = filterFn
-	actual, err := r.filter("prompt", "id", "url", []string{"tag"})
+	actual, err := r.filter("prompt", "", "id", "url", []string{"tag"})
 	if err != nil {
 		t.Fatal(err.Error())
 	}
@@ -80,7 +111,7 @@ func TestFilterTwoMarks(t *testing.T) {
 	}
 	r.markService.(*mocks.MarkService).FilterFn = filterFn
 	r.prompter.(*mocks.Prompter).SelectFn = selectFn
-	actual, err := r.filter("prompt", "id", "url", []string{"tag"})
+	actual, err := r.filter("prompt", "", "id", "url", []string{"tag"})
 	if err != nil {
 		t.Fatal(err.Error())
 	}
@@ -88,3 +119,59 @@ func TestFilterTwoMarks(t *testing.T) {
 		t.Fatalf("expected %v, received %v", expected, actual)
 	}
 }
+
+func TestFilterRanksMarksBeforeSelecting(t *testing.T) {
+	rare := &marks.Mark{Id: "rare", Visits: 1, LastOpened: marks.Timestamp(testNow)}
+	frequent := &marks.Mark{Id: "frequent", Visits: 10, LastOpened: marks.Timestamp(testNow)}
+	r := newTestRunner()
+	r.config.Rank = "frecency"
+	r.markService.(*mocks.MarkService).FilterFn = func(string, string, []string) ([]*marks.Mark, error) {
+		return []*marks.Mark{rare, frequent}, nil
+	}
+	r.prompter.(*mocks.Prompter).SelectFn = func(s string, table []string) (int, error) {
+		return 0, nil
+	}
+	actual, err := r.filter("prompt", "", "", "", []string{})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if actual != frequent {
+		t.Fatalf("expected %v, received %v", frequent, actual)
+	}
+}
+
+func TestFilterWithQuery(t *testing.T) {
+	expected := mocks.DefaultMarks[0]
+	r := newTestRunner()
+	r.markService.(*mocks.MarkService).SearchFn = func(expr string) ([]*marks.Mark, error) {
+		if expr != "(tag:news OR tag:uk) AND id:abc" {
+			t.Fatalf("unexpected query %v", expr)
+		}
+		return []*marks.Mark{expected}, nil
+	}
+	actual, err := r.filter("prompt", "tag:news OR tag:uk", "abc", "", []string{})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if actual != expected {
+		t.Fatalf("expected %v, received %v", expected, actual)
+	}
+	if r.markService.(*mocks.MarkService).FilterFnCalled {
+		t.Fatal("filter should not be called")
+	}
+}
+
+func TestFilterWithQueryZeroMarks(t *testing.T) {
+	r := newTestRunner()
+	r.markService.(*mocks.MarkService).SearchFn = func(expr string) ([]*marks.Mark, error) {
+		return []*marks.Mark{}, nil
+	}
+	_, err := r.filter("prompt", "tag:nothing", "", "", []string{})
+	if _, ok := err.(*runnerError); !ok {
+		t.Fatal("expected runnerError")
+	}
+	expected := "No bookmarks found matching: tag:nothing"
+	if err.Error() != expected {
+		t.Fatalf("expected %v, received %v", expected, err.Error())
+	}
+}
@@ -0,0 +1,189 @@
+package runner
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tomguerney/marks/marks"
+)
+
+type saveSearch struct {
+	args          *SaveSearchArgs
+	config        *marks.Config
+	searchService marks.SearchService
+	printer       marks.Printer
+}
+
+type SaveSearchArgs struct {
+	name  string
+	query string
+	edit  bool
+}
+
+type listSearches struct {
+	config        *marks.Config
+	searchService marks.SearchService
+	printer       marks.Printer
+}
+
+type deleteSearch struct {
+	args          *DeleteSearchArgs
+	config        *marks.Config
+	searchService marks.SearchService
+	printer       marks.Printer
+	prompter      marks.Prompter
+}
+
+type DeleteSearchArgs struct {
+	name string
+}
+
+func NewSaveSearchRunner(
+	args *SaveSearchArgs,
+	config *marks.Config,
+	searchService marks.SearchService,
+	printer marks.Printer,
+) *saveSearch {
+	return &saveSearch{
+		args,
+		config,
+		searchService,
+		printer,
+	}
+}
+
+// NewSaveSearchArgs describes a search to save. With edit set the search
+// must already exist and its query is replaced, otherwise it must not.
+func NewSaveSearchArgs(name, query string, edit bool) *SaveSearchArgs {
+	return &SaveSearchArgs{name, query, edit}
+}
+
+func NewListSearchesRunner(
+	config *marks.Config,
+	searchService marks.SearchService,
+	printer marks.Printer,
+) *listSearches {
+	return &listSearches{
+		config,
+		searchService,
+		printer,
+	}
+}
+
+func NewDeleteSearchRunner(
+	args *DeleteSearchArgs,
+	config *marks.Config,
+	searchService marks.SearchService,
+	printer marks.Printer,
+	prompter marks.Prompter,
+) *deleteSearch {
+	return &deleteSearch{
+		args,
+		config,
+		searchService,
+		printer,
+		prompter,
+	}
+}
+
+func NewDeleteSearchArgs(name string) *DeleteSearchArgs {
+	return &DeleteSearchArgs{name}
+}
+
+func (s *saveSearch) Run() error {
+
+	name := strings.TrimPrefix(s.args.name, "@")
+
+	existing, err := s.searchService.Search(name)
+	if err != nil {
+		return err
+	}
+
+	if existing != nil && !s.args.edit {
+		s.printer.Error("Saved search \"%v\" already exists", name)
+		return nil
+	}
+
+	if existing == nil && s.args.edit {
+		s.printer.Error("No saved search named \"%v\"", name)
+		return nil
+	}
+
+	if s.args.query == "" {
+		s.printer.Error("Saved search \"%v\" needs a query, tags, url or id to search for", name)
+		return nil
+	}
+
+	err = s.searchService.Save(&marks.Search{Name: name, Query: s.args.query})
+	if err != nil {
+		return err
+	}
+
+	s.printer.Msg("Saved search @%v: %v", name, s.args.query)
+
+	return s.printer.Record("search save")
+}
+
+func (l *listSearches) Run() error {
+
+	searches, err := l.searchService.Searches()
+	if err != nil {
+		return err
+	}
+
+	if len(searches) == 0 {
+		l.printer.Msg("No saved searches")
+		return l.printer.RecordSearches("search list", searches)
+	}
+
+	table, err := l.printer.TabulateSearches(searches)
+	if err != nil {
+		return err
+	}
+
+	l.printer.Msg("%v", strings.Join(table, "\n"))
+
+	return l.printer.RecordSearches("search list", searches)
+}
+
+func (d *deleteSearch) Run() error {
+
+	name := strings.TrimPrefix(d.args.name, "@")
+
+	existing, err := d.searchService.Search(name)
+	if err != nil {
+		return err
+	}
+
+	if existing == nil {
+		d.printer.Error("No saved search named \"%v\"", name)
+		return nil
+	}
+
+	d.printer.Msg("Selected: @%v %v", existing.Name, existing.Query)
+
+	if !d.prompter.Confirm("Are you sure you want to delete") {
+		d.printer.Msg("Exiting")
+		return nil
+	}
+
+	if err := d.searchService.Delete(existing.Name); err != nil {
+		return err
+	}
+
+	d.printer.Msg("Deleted")
+
+	return d.printer.Record("search delete")
+}
+
+// withSavedSearch moves an @name id, as in "marks open @daily", into the
+// query expression, where it refers to the saved search.
+func withSavedSearch(expr, id string) (string, string) {
+	if !strings.HasPrefix(id, "@") {
+		return expr, id
+	}
+	if expr == "" {
+		return id, ""
+	}
+	return fmt.Sprintf("(%v) %v", expr, id), ""
+}
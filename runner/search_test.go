@@ -0,0 +1,135 @@
+package runner
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/tomguerney/marks/marks"
+	"github.com/tomguerney/marks/mocks"
+)
+
+func newTestSaveSearchRunner() *saveSearch {
+	return &saveSearch{
+		args:          &SaveSearchArgs{},
+		config:        mocks.NewConfig(),
+		searchService: mocks.NewSearchService(),
+		printer:       mocks.NewPrinter(),
+	}
+}
+
+func TestSaveSearch(t *testing.T) {
+	r := newTestSaveSearchRunner()
+	r.args = &SaveSearchArgs{name: "@daily", query: "tag:standup AND url:jira"}
+	r.searchService.(*mocks.SearchService).SaveFn = func(actual *marks.Search) error {
+		expected := &marks.Search{Name: "daily", Query: "tag:standup AND url:jira"}
+		if !reflect.DeepEqual(actual, expected) {
+			t.Fatalf("expected %v, received %v", expected, actual)
+		}
+		return nil
+	}
+	if err := r.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if !r.searchService.(*mocks.SearchService).SaveFnCalled {
+		t.Fatal("save should be called")
+	}
+}
+
+func TestSaveSearchAlreadyExists(t *testing.T) {
+	r := newTestSaveSearchRunner()
+	r.args = &SaveSearchArgs{name: "daily", query: "tag:standup"}
+	r.searchService.(*mocks.SearchService).SearchFn = func(name string) (*marks.Search, error) {
+		return mocks.DefaultSearches[0], nil
+	}
+	if err := r.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if !r.printer.(*mocks.Printer).ErrorFnCalled || r.searchService.(*mocks.SearchService).SaveFnCalled {
+		t.Fatal("error should be printed and save should not be called")
+	}
+}
+
+func TestEditSearch(t *testing.T) {
+	r := newTestSaveSearchRunner()
+	r.args = &SaveSearchArgs{name: "daily", query: "tag:standup", edit: true}
+	r.searchService.(*mocks.SearchService).SearchFn = func(name string) (*marks.Search, error) {
+		return mocks.DefaultSearches[0], nil
+	}
+	if err := r.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if !r.searchService.(*mocks.SearchService).SaveFnCalled {
+		t.Fatal("save should be called")
+	}
+}
+
+func TestEditMissingSearch(t *testing.T) {
+	r := newTestSaveSearchRunner()
+	r.args = &SaveSearchArgs{name: "missing", query: "tag:standup", edit: true}
+	if err := r.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if !r.printer.(*mocks.Printer).ErrorFnCalled || r.searchService.(*mocks.SearchService).SaveFnCalled {
+		t.Fatal("error should be printed and save should not be called")
+	}
+}
+
+func TestListSearches(t *testing.T) {
+	r := &listSearches{mocks.NewConfig(), mocks.NewSearchService(), mocks.NewPrinter()}
+	var tabulated []*marks.Search
+	r.printer.(*mocks.Printer).TabulateSearchesFn = func(searches []*marks.Search) ([]string, error) {
+		tabulated = searches
+		return []string{"row"}, nil
+	}
+	var recorded []*marks.Search
+	r.printer.(*mocks.Printer).RecordSearchesFn = func(action string, searches []*marks.Search) error {
+		recorded = searches
+		return nil
+	}
+	if err := r.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if !reflect.DeepEqual(tabulated, mocks.DefaultSearches) {
+		t.Fatalf("expected %v, received %v", mocks.DefaultSearches, tabulated)
+	}
+	if !reflect.DeepEqual(recorded, mocks.DefaultSearches) {
+		t.Fatalf("expected %v to be recorded, received %v", mocks.DefaultSearches, recorded)
+	}
+}
+
+func TestDeleteSearch(t *testing.T) {
+	r := &deleteSearch{
+		&DeleteSearchArgs{"daily"},
+		mocks.NewConfig(),
+		mocks.NewSearchService(),
+		mocks.NewPrinter(),
+		mocks.NewPrompter(),
+	}
+	r.searchService.(*mocks.SearchService).SearchFn = func(name string) (*marks.Search, error) {
+		return mocks.DefaultSearches[0], nil
+	}
+	if err := r.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if !r.prompter.(*mocks.Prompter).ConfirmFnCalled ||
+		!r.searchService.(*mocks.SearchService).DeleteFnCalled {
+		t.Fatal("confirm and delete should be called")
+	}
+}
+
+func TestWithSavedSearch(t *testing.T) {
+	tests := []struct {
+		expr, id                 string
+		expectedExpr, expectedId string
+	}{
+		{"", "abc", "", "abc"},
+		{"", "@daily", "@daily", ""},
+		{"tag:a OR tag:b", "@daily", "(tag:a OR tag:b) @daily", ""},
+	}
+	for _, test := range tests {
+		expr, id := withSavedSearch(test.expr, test.id)
+		if expr != test.expectedExpr || id != test.expectedId {
+			t.Fatalf("expected %v, %v, received %v, %v", test.expectedExpr, test.expectedId, expr, id)
+		}
+	}
+}
@@ -0,0 +1,256 @@
+package runner
+
+import (
+	"strings"
+
+	"github.com/tomguerney/marks/marks"
+)
+
+type saveSession struct {
+	args           *SaveSessionArgs
+	config         *marks.Config
+	markService    marks.MarkService
+	sessionService marks.SessionService
+	printer        marks.Printer
+}
+
+type SaveSessionArgs struct {
+	name string
+	ids  []string
+}
+
+type openSession struct {
+	*open
+	args           *OpenSessionArgs
+	sessionService marks.SessionService
+}
+
+type OpenSessionArgs struct {
+	name    string
+	browser string
+	explain bool
+}
+
+type listSessions struct {
+	config         *marks.Config
+	sessionService marks.SessionService
+	printer        marks.Printer
+}
+
+type deleteSession struct {
+	args           *DeleteSessionArgs
+	config         *marks.Config
+	sessionService marks.SessionService
+	printer        marks.Printer
+	prompter       marks.Prompter
+}
+
+type DeleteSessionArgs struct {
+	name string
+}
+
+func NewSaveSessionRunner(
+	args *SaveSessionArgs,
+	config *marks.Config,
+	markService marks.MarkService,
+	sessionService marks.SessionService,
+	printer marks.Printer,
+) *saveSession {
+	return &saveSession{
+		args,
+		config,
+		markService,
+		sessionService,
+		printer,
+	}
+}
+
+// NewSaveSessionArgs describes a session of the marks with the given ids, in
+// the order they will be opened.
+func NewSaveSessionArgs(name string, ids []string) *SaveSessionArgs {
+	return &SaveSessionArgs{name, ids}
+}
+
+func NewOpenSessionRunner(
+	args *OpenSessionArgs,
+	config *marks.Config,
+	markService marks.MarkService,
+	sessionService marks.SessionService,
+	printer marks.Printer,
+	prompter marks.Prompter,
+	opener opener,
+) *openSession {
+	return &openSession{
+		NewOpenRunner(
+			&OpenArgs{browser: args.browser, explain: args.explain},
+			config,
+			markService,
+			printer,
+			prompter,
+			opener,
+		),
+		args,
+		sessionService,
+	}
+}
+
+// NewOpenSessionArgs names the session to open. As with open, a browser
+// given here overrides any routes.
+func NewOpenSessionArgs(name, browser string, explain bool) *OpenSessionArgs {
+	return &OpenSessionArgs{name, browser, explain}
+}
+
+func NewListSessionsRunner(
+	config *marks.Config,
+	sessionService marks.SessionService,
+	printer marks.Printer,
+) *listSessions {
+	return &listSessions{
+		config,
+		sessionService,
+		printer,
+	}
+}
+
+func NewDeleteSessionRunner(
+	args *DeleteSessionArgs,
+	config *marks.Config,
+	sessionService marks.SessionService,
+	printer marks.Printer,
+	prompter marks.Prompter,
+) *deleteSession {
+	return &deleteSession{
+		args,
+		config,
+		sessionService,
+		printer,
+		prompter,
+	}
+}
+
+func NewDeleteSessionArgs(name string) *DeleteSessionArgs {
+	return &DeleteSessionArgs{name}
+}
+
+func (s *saveSession) Run() error {
+
+	if len(s.args.ids) == 0 {
+		s.printer.Error("Session \"%v\" needs at least one bookmark id", s.args.name)
+		return nil
+	}
+
+	all, err := s.markService.Marks()
+	if err != nil {
+		return err
+	}
+
+	session := &marks.Session{Name: s.args.name, Marks: []string{}}
+	mks := []*marks.Mark{}
+	for _, id := range s.args.ids {
+		m := findMark(all, id)
+		if m == nil {
+			s.printer.Error("No bookmark with id \"%v\"", id)
+			return nil
+		}
+		session.Marks = append(session.Marks, m.Id)
+		mks = append(mks, m)
+	}
+
+	if err := s.sessionService.Save(session); err != nil {
+		return err
+	}
+
+	s.printer.Msg("Saved session %v: %v", session.Name, strings.Join(session.Marks, ", "))
+
+	return s.printer.Record("session save", mks...)
+}
+
+func (o *openSession) Run() error {
+
+	session, err := o.sessionService.Session(o.args.name)
+	if err != nil {
+		return err
+	}
+
+	if session == nil {
+		o.printer.Error("No session named \"%v\"", o.args.name)
+		return nil
+	}
+
+	all, err := o.markService.Marks()
+	if err != nil {
+		return err
+	}
+
+	mks := []*marks.Mark{}
+	for _, id := range session.Marks {
+		m := findMark(all, id)
+		if m == nil {
+			o.printer.Msg("Warning: bookmark \"%v\" in session %v no longer exists", id, session.Name)
+			continue
+		}
+		mks = append(mks, m)
+	}
+
+	return o.launch(mks, nil)
+}
+
+func (l *listSessions) Run() error {
+
+	sessions, err := l.sessionService.Sessions()
+	if err != nil {
+		return err
+	}
+
+	if len(sessions) == 0 {
+		l.printer.Msg("No sessions")
+		return l.printer.RecordSessions("session list", sessions)
+	}
+
+	table, err := l.printer.TabulateSessions(sessions)
+	if err != nil {
+		return err
+	}
+
+	l.printer.Msg("%v", strings.Join(table, "\n"))
+
+	return l.printer.RecordSessions("session list", sessions)
+}
+
+func (d *deleteSession) Run() error {
+
+	existing, err := d.sessionService.Session(d.args.name)
+	if err != nil {
+		return err
+	}
+
+	if existing == nil {
+		d.printer.Error("No session named \"%v\"", d.args.name)
+		return nil
+	}
+
+	d.printer.Msg("Selected: %v %v", existing.Name, strings.Join(existing.Marks, ", "))
+
+	if !d.prompter.Confirm("Are you sure you want to delete") {
+		d.printer.Msg("Exiting")
+		return nil
+	}
+
+	if err := d.sessionService.Delete(existing.Name); err != nil {
+		return err
+	}
+
+	d.printer.Msg("Deleted")
+
+	return d.printer.Record("session delete")
+}
+
+// findMark returns the mark with the id, ignoring case, if there is one.
+func findMark(mks []*marks.Mark, id string) *marks.Mark {
+	for _, m := range mks {
+		if strings.EqualFold(m.Id, id) {
+			return m
+		}
+	}
+	return nil
+}
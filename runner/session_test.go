@@ -0,0 +1,153 @@
+package runner
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/tomguerney/marks/marks"
+	"github.com/tomguerney/marks/mocks"
+)
+
+func newTestOpenSessionRunner() *openSession {
+	return &openSession{
+		open:           newTestOpenRunner(),
+		args:           &OpenSessionArgs{name: "morning"},
+		sessionService: mocks.NewSessionService(),
+	}
+}
+
+func TestSaveSession(t *testing.T) {
+	r := &saveSession{
+		args:           &SaveSessionArgs{name: "morning", ids: []string{"google", "abc news"}},
+		config:         mocks.NewConfig(),
+		markService:    mocks.NewMarkService(),
+		sessionService: mocks.NewSessionService(),
+		printer:        mocks.NewPrinter(),
+	}
+	r.sessionService.(*mocks.SessionService).SaveFn = func(actual *marks.Session) error {
+		expected := &marks.Session{Name: "morning", Marks: []string{"Google", "Abc News"}}
+		if !reflect.DeepEqual(actual, expected) {
+			t.Fatalf("expected %v, received %v", expected, actual)
+		}
+		return nil
+	}
+	if err := r.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if !r.sessionService.(*mocks.SessionService).SaveFnCalled {
+		t.Fatal("save should be called")
+	}
+}
+
+func TestSaveSessionUnknownMark(t *testing.T) {
+	r := &saveSession{
+		args:           &SaveSessionArgs{name: "morning", ids: []string{"Google", "Slack"}},
+		config:         mocks.NewConfig(),
+		markService:    mocks.NewMarkService(),
+		sessionService: mocks.NewSessionService(),
+		printer:        mocks.NewPrinter(),
+	}
+	if err := r.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if !r.printer.(*mocks.Printer).ErrorFnCalled ||
+		r.sessionService.(*mocks.SessionService).SaveFnCalled {
+		t.Fatal("error should be printed without saving")
+	}
+}
+
+func TestOpenSession(t *testing.T) {
+	r := newTestOpenSessionRunner()
+	r.sessionService.(*mocks.SessionService).SessionFn = func(name string) (*marks.Session, error) {
+		return &marks.Session{Name: "morning", Marks: []string{"BBC News", "Slack", "Abc News"}}, nil
+	}
+	var opened []string
+	r.opener.(*mocks.Opener).OpenFn = func(url, browser, profile string) error {
+		opened = append(opened, url)
+		return nil
+	}
+	var warned bool
+	r.printer.(*mocks.Printer).MsgFn = func(msg string, i ...interface{}) {
+		warned = warned || msg == "Warning: bookmark \"%v\" in session %v no longer exists"
+	}
+	if err := r.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+	expected := []string{mocks.DefaultMarks[2].Url, mocks.DefaultMarks[0].Url}
+	if !reflect.DeepEqual(opened, expected) {
+		t.Fatalf("expected %v, received %v", expected, opened)
+	}
+	if !warned {
+		t.Fatal("missing bookmark should be warned about")
+	}
+}
+
+func TestOpenSessionOverThreshold(t *testing.T) {
+	r := newTestOpenSessionRunner()
+	r.config.OpenThreshold = 1
+	r.sessionService.(*mocks.SessionService).SessionFn = func(name string) (*marks.Session, error) {
+		return mocks.DefaultSessions[0], nil
+	}
+	var label string
+	r.prompter.(*mocks.Prompter).ConfirmFn = func(actual string) bool {
+		label = actual
+		return false
+	}
+	if err := r.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if label != "Open 2 bookmarks" {
+		t.Fatalf("unexpected confirm label %v", label)
+	}
+	if r.opener.(*mocks.Opener).OpenFnCalled {
+		t.Fatal("open should not be called")
+	}
+}
+
+func TestOpenSessionNotFound(t *testing.T) {
+	r := newTestOpenSessionRunner()
+	if err := r.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if !r.printer.(*mocks.Printer).ErrorFnCalled ||
+		r.opener.(*mocks.Opener).OpenFnCalled {
+		t.Fatal("error should be printed without opening")
+	}
+}
+
+func TestListSessions(t *testing.T) {
+	r := NewListSessionsRunner(mocks.NewConfig(), mocks.NewSessionService(), mocks.NewPrinter())
+	var recorded []*marks.Session
+	r.printer.(*mocks.Printer).RecordSessionsFn = func(action string, sessions []*marks.Session) error {
+		recorded = sessions
+		return nil
+	}
+	if err := r.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if !r.printer.(*mocks.Printer).TabulateSessionsFnCalled {
+		t.Fatal("sessions should be tabulated")
+	}
+	if !reflect.DeepEqual(recorded, mocks.DefaultSessions) {
+		t.Fatalf("expected %v to be recorded, received %v", mocks.DefaultSessions, recorded)
+	}
+}
+
+func TestDeleteSession(t *testing.T) {
+	r := NewDeleteSessionRunner(
+		NewDeleteSessionArgs("morning"),
+		mocks.NewConfig(),
+		mocks.NewSessionService(),
+		mocks.NewPrinter(),
+		mocks.NewPrompter(),
+	)
+	r.sessionService.(*mocks.SessionService).SessionFn = func(name string) (*marks.Session, error) {
+		return mocks.DefaultSessions[0], nil
+	}
+	if err := r.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if !r.sessionService.(*mocks.SessionService).DeleteFnCalled {
+		t.Fatal("delete should be called")
+	}
+}
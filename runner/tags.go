@@ -0,0 +1,253 @@
+package runner
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tomguerney/marks/marks"
+)
+
+type listTags struct {
+	config      *marks.Config
+	markService marks.MarkService
+	printer     marks.Printer
+}
+
+type treeTags struct {
+	config      *marks.Config
+	markService marks.MarkService
+	printer     marks.Printer
+}
+
+type retag struct {
+	*runner
+	args *RetagArgs
+}
+
+type normalizeTags struct {
+	*runner
+}
+
+// RetagArgs replaces the tags in from with to across every mark, or
+// deletes them if to is empty.
+type RetagArgs struct {
+	from []string
+	to   string
+}
+
+func NewListTagsRunner(
+	config *marks.Config,
+	markService marks.MarkService,
+	printer marks.Printer,
+) *listTags {
+	return &listTags{
+		config,
+		markService,
+		printer,
+	}
+}
+
+func NewTreeTagsRunner(
+	config *marks.Config,
+	markService marks.MarkService,
+	printer marks.Printer,
+) *treeTags {
+	return &treeTags{
+		config,
+		markService,
+		printer,
+	}
+}
+
+func NewRetagRunner(
+	args *RetagArgs,
+	config *marks.Config,
+	markService marks.MarkService,
+	printer marks.Printer,
+	prompter marks.Prompter,
+) *retag {
+	return &retag{
+		newRunner(config, markService, printer, prompter),
+		args,
+	}
+}
+
+func NewNormalizeTagsRunner(
+	config *marks.Config,
+	markService marks.MarkService,
+	printer marks.Printer,
+	prompter marks.Prompter,
+) *normalizeTags {
+	return &normalizeTags{
+		newRunner(config, markService, printer, prompter),
+	}
+}
+
+func NewRenameTagArgs(old, new string) *RetagArgs {
+	return &RetagArgs{[]string{old}, new}
+}
+
+func NewMergeTagsArgs(tags []string, into string) *RetagArgs {
+	return &RetagArgs{tags, into}
+}
+
+func NewDeleteTagsArgs(tags []string) *RetagArgs {
+	return &RetagArgs{tags, ""}
+}
+
+func (l *listTags) Run() error {
+
+	mks, err := l.markService.Marks()
+	if err != nil {
+		return err
+	}
+
+	counts := marks.CountTags(mks)
+
+	if len(counts) == 0 {
+		l.printer.Msg("No tags found")
+		return l.printer.RecordTagCounts("tags list", counts)
+	}
+
+	table, err := l.printer.TabulateTagCounts(counts)
+	if err != nil {
+		return err
+	}
+
+	l.printer.Msg("%v", strings.Join(table, "\n"))
+
+	return l.printer.RecordTagCounts("tags list", counts)
+}
+
+func (t *treeTags) Run() error {
+
+	mks, err := t.markService.Marks()
+	if err != nil {
+		return err
+	}
+
+	tree := marks.TagTree(mks, t.config.TagSeparator)
+
+	if len(tree) == 0 {
+		t.printer.Msg("No tags found")
+		return t.printer.RecordTagTree("tags tree", tree)
+	}
+
+	lines, err := t.printer.TabulateTagTree(tree)
+	if err != nil {
+		return err
+	}
+
+	t.printer.Msg("%v", strings.Join(lines, "\n"))
+
+	return t.printer.RecordTagTree("tags tree", tree)
+}
+
+func (r *retag) Run() error {
+
+	changed, err := r.rewriteTags(func(m *marks.Mark) bool {
+		return m.Retag(r.args.from, r.args.to, r.config.TagSeparator)
+	}, r.describe(), fmt.Sprintf("tags %v", r.action()))
+
+	if err == nil && changed == 0 {
+		r.printer.Error("No bookmarks tagged %v", quoteTags(r.args.from))
+	}
+
+	return err
+}
+
+func (n *normalizeTags) Run() error {
+
+	normalizer := marks.NewTagNormalizer(n.config)
+
+	changed, err := n.rewriteTags(func(m *marks.Mark) bool {
+		return m.NormalizeTags(normalizer)
+	}, "Normalizing tags", "tags normalize")
+
+	if err == nil && changed == 0 {
+		n.printer.Msg("All tags are already normalized")
+		return n.printer.Record("tags normalize")
+	}
+
+	return err
+}
+
+// rewriteTags applies rewrite to a copy of every mark, previews the marks it
+// changed and, once confirmed, saves them. It returns how many marks rewrite
+// changed.
+func (r *runner) rewriteTags(rewrite func(*marks.Mark) bool, describe, action string) (int, error) {
+
+	mks, err := r.markService.Marks()
+	if err != nil {
+		return 0, err
+	}
+
+	updates := map[string]*marks.Mark{}
+	changed := []*marks.Mark{}
+	for _, m := range mks {
+		updated := m.Copy()
+		if rewrite(updated) {
+			updated.Updated = marks.Timestamp(now())
+			updates[m.Id] = updated
+			changed = append(changed, updated)
+		}
+	}
+
+	if len(changed) == 0 {
+		return 0, nil
+	}
+
+	table, err := r.printer.Tabulate(changed)
+	if err != nil {
+		return 0, err
+	}
+
+	r.printer.Msg("%v in %v bookmarks:", describe, len(changed))
+	r.printer.Msg("%v", strings.Join(table, "\n"))
+
+	if !r.prompter.Confirm("Are you sure you want to continue") {
+		r.printer.Msg("Exiting")
+		return len(changed), nil
+	}
+
+	if err := r.markService.UpdateAll(updates); err != nil {
+		return 0, err
+	}
+
+	r.printer.Msg("Updated %v bookmarks", len(changed))
+
+	return len(changed), r.printer.Record(action, changed...)
+}
+
+func (r *retag) action() string {
+	switch {
+	case r.args.to == "":
+		return "delete"
+	case len(r.args.from) == 1:
+		return "rename"
+	default:
+		return "merge"
+	}
+}
+
+func (r *retag) describe() string {
+	switch r.action() {
+	case "delete":
+		return fmt.Sprintf("Deleting %v", quoteTags(r.args.from))
+	case "rename":
+		return fmt.Sprintf("Renaming %v to \"%v\"", quoteTags(r.args.from), r.args.to)
+	default:
+		return fmt.Sprintf("Merging %v into \"%v\"", quoteTags(r.args.from), r.args.to)
+	}
+}
+
+func quoteTags(tags []string) string {
+	quoted := []string{}
+	for _, tag := range tags {
+		quoted = append(quoted, fmt.Sprintf("\"%v\"", tag))
+	}
+	if len(quoted) == 1 {
+		return fmt.Sprintf("tag %v", quoted[0])
+	}
+	return fmt.Sprintf("tags %v", strings.Join(quoted, ", "))
+}
@@ -0,0 +1,202 @@
+package runner
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/tomguerney/marks/marks"
+	"github.com/tomguerney/marks/mocks"
+)
+
+func newTestRetagRunner(args *RetagArgs) *retag {
+	return &retag{
+		runner: newTestRunner(),
+		args:   args,
+	}
+}
+
+func TestListTags(t *testing.T) {
+	r := &listTags{mocks.NewConfig(), mocks.NewMarkService(), mocks.NewPrinter()}
+	var counts []*marks.TagCount
+	r.printer.(*mocks.Printer).TabulateTagCountsFn = func(actual []*marks.TagCount) ([]string, error) {
+		counts = actual
+		return []string{"row"}, nil
+	}
+	var recorded []*marks.TagCount
+	r.printer.(*mocks.Printer).RecordTagCountsFn = func(action string, actual []*marks.TagCount) error {
+		recorded = actual
+		return nil
+	}
+	if err := r.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+	expected := []*marks.TagCount{
+		&marks.TagCount{Tag: "news", Count: 2},
+		&marks.TagCount{Tag: "current affairs", Count: 1},
+		&marks.TagCount{Tag: "search", Count: 1},
+		&marks.TagCount{Tag: "uk", Count: 1},
+	}
+	if !reflect.DeepEqual(counts, expected) {
+		t.Fatalf("expected %v, received %v", expected, counts)
+	}
+	if !reflect.DeepEqual(recorded, expected) {
+		t.Fatalf("expected %v to be recorded, received %v", expected, recorded)
+	}
+}
+
+func TestTreeTags(t *testing.T) {
+	r := &treeTags{mocks.NewConfig(), mocks.NewMarkService(), mocks.NewPrinter()}
+	r.config.TagSeparator = "/"
+	r.markService.(*mocks.MarkService).MarksFn = func() ([]*marks.Mark, error) {
+		return []*marks.Mark{
+			&marks.Mark{Id: "ci", Tags: []string{"work/projectx/ci"}},
+			&marks.Mark{Id: "admin", Tags: []string{"work/admin"}},
+		}, nil
+	}
+	var tree []*marks.TagNode
+	r.printer.(*mocks.Printer).TabulateTagTreeFn = func(actual []*marks.TagNode) ([]string, error) {
+		tree = actual
+		return []string{"row"}, nil
+	}
+	if err := r.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+	expected := []*marks.TagNode{
+		&marks.TagNode{Name: "work", Path: "work", Count: 2, Children: []*marks.TagNode{
+			&marks.TagNode{Name: "admin", Path: "work/admin", Count: 1},
+			&marks.TagNode{Name: "projectx", Path: "work/projectx", Count: 1, Children: []*marks.TagNode{
+				&marks.TagNode{Name: "ci", Path: "work/projectx/ci", Count: 1},
+			}},
+		}},
+	}
+	if !reflect.DeepEqual(tree, expected) {
+		t.Fatalf("expected %v, received %v", expected, tree)
+	}
+}
+
+func TestRenameParentTag(t *testing.T) {
+	r := newTestRetagRunner(NewRenameTagArgs("work", "job"))
+	r.config.TagSeparator = "/"
+	r.markService.(*mocks.MarkService).MarksFn = func() ([]*marks.Mark, error) {
+		return []*marks.Mark{
+			&marks.Mark{Id: "ci", Tags: []string{"work/projectx/ci"}},
+			&marks.Mark{Id: "workshop", Tags: []string{"workshop"}},
+		}, nil
+	}
+	r.markService.(*mocks.MarkService).UpdateAllFn = func(updates map[string]*marks.Mark) error {
+		expected := map[string]*marks.Mark{
+			"ci": &marks.Mark{Id: "ci", Tags: []string{"job/projectx/ci"}, Updated: marks.Timestamp(testNow)},
+		}
+		if !reflect.DeepEqual(updates, expected) {
+			t.Fatalf("expected %v, received %v", expected, updates)
+		}
+		return nil
+	}
+	if err := r.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if !r.markService.(*mocks.MarkService).UpdateAllFnCalled {
+		t.Fatal("update all should be called")
+	}
+}
+
+func TestRenameTag(t *testing.T) {
+	r := newTestRetagRunner(NewRenameTagArgs("news", "headlines"))
+	r.markService.(*mocks.MarkService).UpdateAllFn = func(updates map[string]*marks.Mark) error {
+		expected := map[string]*marks.Mark{
+			"Abc News": &marks.Mark{
+				Id:      "Abc News",
+				Url:     "https://www.abc.net.au/news/",
+				Tags:    []string{"headlines", "current affairs"},
+				Updated: marks.Timestamp(testNow),
+			},
+			"BBC News": &marks.Mark{
+				Id:      "BBC News",
+				Url:     "https://www.bbc.com/news",
+				Tags:    []string{"headlines", "uk"},
+				Updated: marks.Timestamp(testNow),
+			},
+		}
+		if !reflect.DeepEqual(updates, expected) {
+			t.Fatalf("expected %v, received %v", expected, updates)
+		}
+		return nil
+	}
+	if err := r.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if !r.prompter.(*mocks.Prompter).ConfirmFnCalled ||
+		!r.markService.(*mocks.MarkService).UpdateAllFnCalled {
+		t.Fatal("confirm and update all should be called")
+	}
+	if mocks.DefaultMarks[0].Tags[0] != "news" {
+		t.Fatal("original marks should not be modified")
+	}
+}
+
+func TestMergeTagsDeclined(t *testing.T) {
+	r := newTestRetagRunner(NewMergeTagsArgs([]string{"uk", "search"}, "misc"))
+	r.prompter.(*mocks.Prompter).ConfirmFn = func(string) bool {
+		return false
+	}
+	if err := r.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if r.markService.(*mocks.MarkService).UpdateAllFnCalled {
+		t.Fatal("update all should not be called")
+	}
+}
+
+func TestDeleteUnusedTag(t *testing.T) {
+	r := newTestRetagRunner(NewDeleteTagsArgs([]string{"unused"}))
+	if err := r.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if !r.printer.(*mocks.Printer).ErrorFnCalled ||
+		r.prompter.(*mocks.Prompter).ConfirmFnCalled {
+		t.Fatal("error should be printed without confirming")
+	}
+}
+
+func TestNormalizeTags(t *testing.T) {
+	r := &normalizeTags{newTestRunner()}
+	r.config.NormalizeTags = true
+	r.config.TagWordSeparator = "-"
+	r.config.TagAliases = map[string]string{"k8s": "kubernetes"}
+	r.markService.(*mocks.MarkService).MarksFn = func() ([]*marks.Mark, error) {
+		return []*marks.Mark{
+			&marks.Mark{Id: "k8s docs", Tags: []string{"K8s", "Current Affairs"}},
+			&marks.Mark{Id: "kubernetes", Tags: []string{"kubernetes"}},
+		}, nil
+	}
+	r.markService.(*mocks.MarkService).UpdateAllFn = func(updates map[string]*marks.Mark) error {
+		expected := map[string]*marks.Mark{
+			"k8s docs": &marks.Mark{
+				Id:      "k8s docs",
+				Tags:    []string{"kubernetes", "current-affairs"},
+				Updated: marks.Timestamp(testNow),
+			},
+		}
+		if !reflect.DeepEqual(updates, expected) {
+			t.Fatalf("expected %v, received %v", expected, updates)
+		}
+		return nil
+	}
+	if err := r.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if !r.markService.(*mocks.MarkService).UpdateAllFnCalled {
+		t.Fatal("update all should be called")
+	}
+}
+
+func TestNormalizeTagsAlreadyNormalized(t *testing.T) {
+	r := &normalizeTags{newTestRunner()}
+	if err := r.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if r.prompter.(*mocks.Prompter).ConfirmFnCalled ||
+		r.markService.(*mocks.MarkService).UpdateAllFnCalled {
+		t.Fatal("should not confirm or update")
+	}
+}
@@ -0,0 +1,64 @@
+package runner
+
+import (
+	"strings"
+
+	"github.com/tomguerney/marks/marks"
+)
+
+type top struct {
+	args        *TopArgs
+	config      *marks.Config
+	markService marks.MarkService
+	printer     marks.Printer
+}
+
+type TopArgs struct {
+	tags  []string
+	limit int
+}
+
+func NewTopRunner(
+	args *TopArgs,
+	config *marks.Config,
+	markService marks.MarkService,
+	printer marks.Printer,
+) *top {
+	return &top{
+		args,
+		config,
+		markService,
+		printer,
+	}
+}
+
+func NewTopArgs(tags []string, limit int) *TopArgs {
+	return &TopArgs{tags, limit}
+}
+
+func (t *top) Run() error {
+	filtered, err := t.markService.Filter("", "", t.args.tags)
+	if err != nil {
+		return err
+	}
+	visited := []*marks.Mark{}
+	for _, m := range filtered {
+		if m.Visits > 0 {
+			visited = append(visited, m)
+		}
+	}
+	if err := marks.Rank(visited, "frecency", now()); err != nil {
+		return err
+	}
+	page := paginate(visited, 0, t.args.limit)
+	if len(page) == 0 {
+		t.printer.Msg("No bookmarks have been opened yet")
+		return t.printer.Record("top")
+	}
+	table, err := t.printer.Tabulate(page)
+	if err != nil {
+		return err
+	}
+	t.printer.Msg("%v", strings.Join(table, "\n"))
+	return t.printer.Record("top", page...)
+}
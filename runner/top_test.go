@@ -0,0 +1,69 @@
+package runner
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/tomguerney/marks/marks"
+	"github.com/tomguerney/marks/mocks"
+)
+
+func newTestTopRunner() *top {
+	return &top{
+		args:        &TopArgs{},
+		config:      mocks.NewConfig(),
+		markService: mocks.NewMarkService(),
+		printer:     mocks.NewPrinter(),
+	}
+}
+
+func TestTopRanksVisitedMarks(t *testing.T) {
+	r := newTestTopRunner()
+	daily := &marks.Mark{Id: "daily", Visits: 20, LastOpened: marks.Timestamp(testNow)}
+	once := &marks.Mark{Id: "once", Visits: 1, LastOpened: marks.Timestamp(testNow)}
+	never := &marks.Mark{Id: "never"}
+	r.markService.(*mocks.MarkService).FilterFn = func(string, string, []string) ([]*marks.Mark, error) {
+		return []*marks.Mark{never, once, daily}, nil
+	}
+	var tabulated []*marks.Mark
+	r.printer.(*mocks.Printer).TabulateFn = func(mks []*marks.Mark) ([]string, error) {
+		tabulated = mks
+		return []string{"row"}, nil
+	}
+	r.args = &TopArgs{limit: 1}
+	if err := r.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+	expected := []*marks.Mark{daily}
+	if !reflect.DeepEqual(tabulated, expected) {
+		t.Fatalf("expected %v, received %v", expected, tabulated)
+	}
+}
+
+func TestTopNoVisitedMarks(t *testing.T) {
+	r := newTestTopRunner()
+	msgFn := func(actual string, i ...interface{}) {
+		expected := "No bookmarks have been opened yet"
+		if actual != expected {
+			t.Fatalf("expected %v, received %v", expected, actual)
+		}
+	}
+	r.printer.(*mocks.Printer).MsgFn = msgFn
+	if err := r.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if r.printer.(*mocks.Printer).TabulateFnCalled {
+		t.Fatal("tabulate should not be called")
+	}
+}
+
+func TestTopFilterError(t *testing.T) {
+	r := newTestTopRunner()
+	r.markService.(*mocks.MarkService).FilterFn = func(string, string, []string) ([]*marks.Mark, error) {
+		return nil, errors.New("error")
+	}
+	if err := r.Run(); err == nil {
+		t.Fatal("should return error")
+	}
+}
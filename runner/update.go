@@ -1,23 +1,32 @@
 package runner
 
 import (
+	"strings"
+
 	"github.com/tomguerney/marks/marks"
 )
 
 type update struct {
 	*runner
-	args *UpdateArgs
+	args   *UpdateArgs
+	editor marks.Editor
 }
 
 type UpdateArgs struct {
-	id         string
-	url        string
-	tags       []string
-	newId      string
-	newUrl     string
-	newTags    []string
-	removeTags []string
-	removeUrl  bool
+	id            string
+	url           string
+	tags          []string
+	newId         string
+	newUrl        string
+	newTags       []string
+	removeTags    []string
+	removeUrl     bool
+	note          string
+	appendNote    string
+	editNote      bool
+	browser       string
+	removeBrowser bool
+	query         string
 }
 
 func NewUpdateRunner(
@@ -26,29 +35,51 @@ func NewUpdateRunner(
 	markService marks.MarkService,
 	printer marks.Printer,
 	prompter marks.Prompter,
+	editor marks.Editor,
 ) *update {
 	return &update{
 		newRunner(config, markService, printer, prompter),
 		args,
+		editor,
 	}
 }
 
-func NewUpdateArgs(id, url, newId, newUrl string, tags, newTags, removeTags []string, removeUrl bool) *UpdateArgs {
+func NewUpdateArgs(
+	id, url, newId, newUrl string,
+	tags, newTags, removeTags []string,
+	removeUrl bool,
+	note, appendNote string,
+	editNote bool,
+	browser string,
+	removeBrowser bool,
+	query string,
+) *UpdateArgs {
 	return &UpdateArgs{
-		id:         id,
-		url:        url,
-		tags:       tags,
-		newId:      newId,
-		newUrl:     newUrl,
-		newTags:    newTags,
-		removeTags: removeTags,
-		removeUrl:  removeUrl,
+		id:            id,
+		url:           url,
+		tags:          tags,
+		newId:         newId,
+		newUrl:        newUrl,
+		newTags:       newTags,
+		removeTags:    removeTags,
+		removeUrl:     removeUrl,
+		note:          note,
+		appendNote:    appendNote,
+		editNote:      editNote,
+		browser:       strings.ToLower(browser),
+		removeBrowser: removeBrowser,
+		query:         query,
 	}
 }
 
 func (u *update) Run() error {
 
-	selected, err := u.filter("Select bookmark to update", u.args.id, u.args.url, u.args.tags)
+	if err := validateBrowser(u.args.browser, u.config.Browsers); err != nil {
+		u.printer.Error("Cannot update bookmark: %v", err.Error())
+		return nil
+	}
+
+	selected, err := u.filter("Select bookmark to update", u.args.query, u.args.id, u.args.url, u.args.tags)
 
 	if err != nil {
 		if err, ok := err.(*runnerError); ok {
@@ -59,23 +90,36 @@ func (u *update) Run() error {
 		}
 	}
 
-	updated := &marks.Mark{
-		Id:   u.updatedId(selected),
-		Url:  u.updatedUrl(selected),
-		Tags: u.updatedTags(selected),
-	}
+	updated := selected.Copy()
+	updated.Id = u.updatedId(selected)
+	updated.Url = u.updatedUrl(selected)
+	updated.Tags = u.updatedTags(selected)
+	updated.Updated = marks.Timestamp(now())
 
 	if _, ok := u.containsRemoveTags(updated); !ok {
 		u.printer.Error("Mark %v does not contain tag %v")
 		return nil
 	}
 
-	updated.Tags = u.removeTags(updated.Tags)
+	updated.Tags = marks.NewTagNormalizer(u.config).NormalizeAll(u.removeTags(updated.Tags))
 
 	if u.args.removeUrl {
 		updated.Url = ""
 	}
 
+	if u.args.browser != "" {
+		updated.Browser = u.args.browser
+	}
+
+	if u.args.removeBrowser {
+		updated.Browser = ""
+	}
+
+	updated.Notes, err = u.updatedNotes(selected)
+	if err != nil {
+		return err
+	}
+
 	if err := u.markService.Update(selected.Id, updated); err != nil {
 		return err
 	}
@@ -92,7 +136,7 @@ func (u *update) Run() error {
 
 	u.printer.Msg("Mark updated from:\n%v\nto:\n%v", printSelected, printUpdated)
 
-	return nil
+	return u.printer.Record("update", updated)
 }
 
 func (u *update) updatedId(selected *marks.Mark) string {
@@ -111,6 +155,26 @@ func (u *update) updatedUrl(selected *marks.Mark) string {
 	}
 }
 
+func (u *update) updatedNotes(selected *marks.Mark) (string, error) {
+	notes := selected.Notes
+	if u.args.note != "" {
+		notes = u.args.note
+	}
+	if u.args.appendNote != "" && strings.TrimSpace(notes) != "" {
+		notes = strings.TrimRight(notes, "\n") + "\n" + u.args.appendNote
+	} else if u.args.appendNote != "" {
+		notes = u.args.appendNote
+	}
+	if u.args.editNote {
+		edited, err := u.editor.Edit(notes, ".md")
+		if err != nil {
+			return "", err
+		}
+		notes = strings.TrimRight(edited, " \t\n")
+	}
+	return notes, nil
+}
+
 func (u *update) updatedTags(selected *marks.Mark) []string {
 	l := len(selected.Tags)
 	c := len(selected.Tags) + len(u.args.newTags)
@@ -121,7 +185,11 @@ func (u *update) updatedTags(selected *marks.Mark) []string {
 
 func (u *update) containsRemoveTags(mark *marks.Mark) (tag string, ok bool) {
 	for _, forRemoval := range u.args.removeTags {
-		if !mark.ContainsTag(forRemoval) {
+		found := false
+		for _, tag := range mark.Tags {
+			found = found || u.sameTag(tag, forRemoval)
+		}
+		if !found {
 			return forRemoval, false
 		}
 	}
@@ -130,13 +198,19 @@ func (u *update) containsRemoveTags(mark *marks.Mark) (tag string, ok bool) {
 
 func (u *update) removeTag(tag string) bool {
 	for _, forRemoval := range u.args.removeTags {
-		if tag == forRemoval {
+		if tag == forRemoval || u.sameTag(tag, forRemoval) {
 			return true
 		}
 	}
 	return false
 }
 
+// sameTag reports whether two tags have the same canonical form.
+func (u *update) sameTag(a, b string) bool {
+	normalizer := marks.NewTagNormalizer(u.config)
+	return strings.EqualFold(normalizer.Normalize(a), normalizer.Normalize(b))
+}
+
 func (u *update) removeTags(tags []string) []string {
 	remainingTags := make([]string, 0, len(tags))
 	for _, tag := range tags {
@@ -13,6 +13,7 @@ func newTestUpdateRunner() *update {
 	return &update{
 		runner: newTestRunner(),
 		args:   &UpdateArgs{},
+		editor: mocks.NewEditor(),
 	}
 }
 
@@ -30,9 +31,10 @@ func TestUpdateSuccess(t *testing.T) {
 	}
 	updateFn := func(id string, actual *marks.Mark) error {
 		expected := &marks.Mark{
-			Id:   "Abc Updated News",
-			Url:  "https://www.updated.com",
-			Tags: []string{"current affairs", "newTag1", "newTag2"},
+			Id:      "Abc Updated News",
+			Url:     "https://www.updated.com",
+			Tags:    []string{"current affairs", "newTag1", "newTag2"},
+			Updated: marks.Timestamp(testNow),
 		}
 		if original.Id != id {
 			t.Fatalf("expected %v, received %v", original.Id, id)
@@ -74,9 +76,10 @@ func TestUpdateSuccessRemoveUrl(t *testing.T) {
 	}
 	updateFn := func(id string, actual *marks.Mark) error {
 		expected := &marks.Mark{
-			Id:   "Abc News",
-			Url:  "",
-			Tags: []string{"current affairs"},
+			Id:      "Abc News",
+			Url:     "",
+			Tags:    []string{"current affairs"},
+			Updated: marks.Timestamp(testNow),
 		}
 		if original.Id != id {
 			t.Fatalf("expected %v, received %v", original.Id, id)
@@ -102,6 +105,64 @@ func TestUpdateSuccessRemoveUrl(t *testing.T) {
 	}
 }
 
+func TestUpdatedNotes(t *testing.T) {
+	tests := []struct {
+		notes      string
+		note       string
+		appendNote string
+		expected   string
+	}{
+		{"", "", "", ""},
+		{"old", "", "", "old"},
+		{"old", "new", "", "new"},
+		{"old\n", "", "more", "old\nmore"},
+		{"", "", "more", "more"},
+		{"old", "new", "more", "new\nmore"},
+	}
+	for _, test := range tests {
+		u := newTestUpdateRunner()
+		u.args.note = test.note
+		u.args.appendNote = test.appendNote
+		actual, err := u.updatedNotes(&marks.Mark{Notes: test.notes})
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if actual != test.expected {
+			t.Fatalf("expected %q, received %q", test.expected, actual)
+		}
+	}
+}
+
+func TestUpdatedNotesInEditor(t *testing.T) {
+	u := newTestUpdateRunner()
+	u.args.appendNote = "more"
+	u.args.editNote = true
+	u.editor.(*mocks.Editor).EditFn = func(text, ext string) (string, error) {
+		if text != "old\nmore" || ext != ".md" {
+			t.Fatalf("unexpected text %q and ext %v", text, ext)
+		}
+		return "edited\n\n", nil
+	}
+	actual, err := u.updatedNotes(&marks.Mark{Notes: "old"})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if actual != "edited" {
+		t.Fatalf("expected edited, received %q", actual)
+	}
+}
+
+func TestUpdatedNotesEditorError(t *testing.T) {
+	u := newTestUpdateRunner()
+	u.args.editNote = true
+	u.editor.(*mocks.Editor).EditFn = func(string, string) (string, error) {
+		return "", errors.New("editor failed")
+	}
+	if _, err := u.updatedNotes(&marks.Mark{}); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
 func TestUpdateRunnerError(t *testing.T) {
 	r := newTestUpdateRunner()
 	msgFn := func(actual string, i ...interface{}) {
@@ -301,6 +362,15 @@ func TestIsRemoveTag(t *testing.T) {
 	}
 }
 
+func TestIsRemoveTagAlias(t *testing.T) {
+	u := newTestUpdateRunner()
+	u.config.TagAliases = map[string]string{"k8s": "kubernetes"}
+	u.args.removeTags = []string{"k8s"}
+	if !u.removeTag("Kubernetes") {
+		t.Fatalf("expected true, received false")
+	}
+}
+
 func TestIsNotRemoveTag(t *testing.T) {
 	removeTags := []string{"removeTag1", "removeTag2"}
 	u := newTestUpdateRunner()
@@ -341,3 +411,53 @@ func TestRemoveTagsHasRemoveTagsWithNotContainedTag(t *testing.T) {
 		t.Fatalf("expected %v, received %v", expected, actual)
 	}
 }
+
+func TestUpdateBrowser(t *testing.T) {
+	r := newTestUpdateRunner()
+	r.args.id = "Google"
+	r.args.browser = "firefox"
+	r.config.Browsers = map[string]*marks.Browser{"firefox": &marks.Browser{Name: "firefox"}}
+	r.markService.(*mocks.MarkService).UpdateFn = func(id string, actual *marks.Mark) error {
+		if actual.Browser != "firefox" {
+			t.Fatalf("expected firefox, received %v", actual.Browser)
+		}
+		return nil
+	}
+	if err := r.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if !r.markService.(*mocks.MarkService).UpdateFnCalled {
+		t.Fatal("update should be called")
+	}
+}
+
+func TestUpdateRemoveBrowser(t *testing.T) {
+	r := newTestUpdateRunner()
+	r.args.removeBrowser = true
+	r.markService.(*mocks.MarkService).FilterFn = func(string, string, []string) ([]*marks.Mark, error) {
+		return []*marks.Mark{&marks.Mark{Id: "Jira", Browser: "chrome"}}, nil
+	}
+	r.markService.(*mocks.MarkService).UpdateFn = func(id string, actual *marks.Mark) error {
+		if actual.Browser != "" {
+			t.Fatalf("expected no browser, received %v", actual.Browser)
+		}
+		return nil
+	}
+	if err := r.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+}
+
+func TestUpdateUnknownBrowser(t *testing.T) {
+	r := newTestUpdateRunner()
+	r.args.browser = "lynx"
+	r.config.Browsers = map[string]*marks.Browser{"firefox": &marks.Browser{Name: "firefox"}}
+	if err := r.Run(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if !r.printer.(*mocks.Printer).ErrorFnCalled ||
+		r.markService.(*mocks.MarkService).FilterFnCalled ||
+		r.markService.(*mocks.MarkService).UpdateFnCalled {
+		t.Fatal("error should be printed before selecting a bookmark")
+	}
+}
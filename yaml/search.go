@@ -0,0 +1,169 @@
+package yaml
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/tomguerney/marks/marks"
+	"github.com/tomguerney/marks/query"
+	"gopkg.in/yaml.v2"
+)
+
+var searchNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]*$`)
+
+type searchService struct {
+	config       *marks.Config
+	readerWriter ReaderWriter
+}
+
+func NewSearchService(config *marks.Config, readerWriter ReaderWriter) *searchService {
+	return &searchService{config, readerWriter}
+}
+
+func (s *searchService) Search(name string) (*marks.Search, error) {
+	searches, err := s.validSearches()
+	if err != nil {
+		return nil, err
+	}
+	return find(searches, name), nil
+}
+
+func (s *searchService) Searches() ([]*marks.Search, error) {
+	return s.validSearches()
+}
+
+// Save creates the search, or replaces the saved search with the same
+// name. The search is validated, including any @name references, first.
+func (s *searchService) Save(new *marks.Search) error {
+	searches, err := s.loadSearches()
+	if err != nil {
+		return err
+	}
+	replaced := false
+	for i, search := range searches {
+		if strings.EqualFold(search.Name, new.Name) {
+			searches[i] = new
+			replaced = true
+		}
+	}
+	if !replaced {
+		searches = append(searches, new)
+	}
+	if err := validateSearches(searches); err != nil {
+		return err
+	}
+	return s.saveSearches(searches)
+}
+
+// Delete removes the saved search, unless another saved search refers to
+// it.
+func (s *searchService) Delete(name string) error {
+	searches, err := s.loadSearches()
+	if err != nil {
+		return err
+	}
+	remaining := []*marks.Search{}
+	for _, search := range searches {
+		if !strings.EqualFold(search.Name, name) {
+			remaining = append(remaining, search)
+		}
+	}
+	if len(remaining) == len(searches) {
+		return marks.SearchDoesNotExistError{}
+	}
+	if err := validateSearches(remaining); err != nil {
+		return err
+	}
+	return s.saveSearches(remaining)
+}
+
+// resolver returns a query.Resolver that looks up the saved searches.
+func (s *searchService) resolver() (query.Resolver, error) {
+	searches, err := s.validSearches()
+	if err != nil {
+		return nil, err
+	}
+	return resolverFor(searches), nil
+}
+
+func resolverFor(searches []*marks.Search) query.Resolver {
+	return func(name string) (string, error) {
+		if search := find(searches, name); search != nil {
+			return search.Query, nil
+		}
+		return "", errors.New(fmt.Sprintf("no saved search named \"%v\"", name))
+	}
+}
+
+func validateSearches(searches []*marks.Search) error {
+	names := map[string]bool{}
+	resolve := resolverFor(searches)
+	for _, search := range searches {
+		if !searchNamePattern.MatchString(search.Name) {
+			return errors.New(fmt.Sprintf("\"%v\" is not a valid saved search name, use letters, numbers, - and _", search.Name))
+		}
+		if names[strings.ToLower(search.Name)] {
+			return errors.New(fmt.Sprintf("saved search \"%v\" is defined more than once", search.Name))
+		}
+		names[strings.ToLower(search.Name)] = true
+		if _, err := query.Parse(search.Query, time.Now(), resolve); err != nil {
+			return errors.New(fmt.Sprintf("saved search \"%v\": %v", search.Name, err.Error()))
+		}
+	}
+	return nil
+}
+
+func find(searches []*marks.Search, name string) *marks.Search {
+	for _, search := range searches {
+		if strings.EqualFold(search.Name, name) {
+			return search
+		}
+	}
+	return nil
+}
+
+func (s *searchService) loadSearches() ([]*marks.Search, error) {
+	searchesYaml, err := s.readerWriter.ReadFile(s.yamlPath())
+	if os.IsNotExist(err) {
+		return []*marks.Search{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	searches := []*marks.Search{}
+	if err := yaml.Unmarshal(searchesYaml, &searches); err != nil {
+		return nil, err
+	}
+	return searches, nil
+}
+
+// validSearches loads the saved searches and checks them before they are
+// used. Save and Delete load them unchecked so that a broken file can still
+// be fixed from the command line.
+func (s *searchService) validSearches() ([]*marks.Search, error) {
+	searches, err := s.loadSearches()
+	if err != nil {
+		return nil, err
+	}
+	if err := validateSearches(searches); err != nil {
+		return nil, errors.New(fmt.Sprintf("%v: %v", s.config.SearchesYamlFile, err.Error()))
+	}
+	return searches, nil
+}
+
+func (s *searchService) saveSearches(searches []*marks.Search) error {
+	searchesYaml, err := yaml.Marshal(searches)
+	if err != nil {
+		return err
+	}
+	return s.readerWriter.WriteFile(s.yamlPath(), searchesYaml, s.config.MarksYamlFileMode)
+}
+
+func (s *searchService) yamlPath() string {
+	return path.Join(s.config.ContentPath, s.config.SearchesYamlFile)
+}
@@ -0,0 +1,168 @@
+package yaml
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/tomguerney/marks/marks"
+	"github.com/tomguerney/marks/mocks"
+)
+
+const testSearchesYaml = `- name: daily
+  query: tag:standup url:jira
+- name: news
+  query: tag:news OR @daily
+`
+
+func newTestSearchService(files map[string][]byte) *searchService {
+	config := mocks.NewConfig()
+	config.SearchesYamlFile = "searches.yaml"
+	return &searchService{
+		config,
+		&mockReaderWriter{
+			func(name string) ([]byte, error) {
+				data, ok := files[name]
+				if !ok {
+					return nil, os.ErrNotExist
+				}
+				return data, nil
+			},
+			func(name string, data []byte, perm uint32) error {
+				files[name] = data
+				return nil
+			},
+		},
+	}
+}
+
+func TestSearches(t *testing.T) {
+	s := newTestSearchService(map[string][]byte{"searches.yaml": []byte(testSearchesYaml)})
+	actual, err := s.Searches()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	expected := []*marks.Search{
+		&marks.Search{Name: "daily", Query: "tag:standup url:jira"},
+		&marks.Search{Name: "news", Query: "tag:news OR @daily"},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %v, received %v", expected, actual)
+	}
+}
+
+func TestSearchesMissingFile(t *testing.T) {
+	s := newTestSearchService(map[string][]byte{})
+	actual, err := s.Searches()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(actual) != 0 {
+		t.Fatalf("expected no searches, received %v", actual)
+	}
+}
+
+func TestSearchByName(t *testing.T) {
+	s := newTestSearchService(map[string][]byte{"searches.yaml": []byte(testSearchesYaml)})
+	actual, err := s.Search("DAILY")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if actual == nil || actual.Name != "daily" {
+		t.Fatalf("expected daily, received %v", actual)
+	}
+	actual, err = s.Search("missing")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if actual != nil {
+		t.Fatalf("expected nil, received %v", actual)
+	}
+}
+
+func TestSaveSearch(t *testing.T) {
+	files := map[string][]byte{"searches.yaml": []byte(testSearchesYaml)}
+	s := newTestSearchService(files)
+	if err := s.Save(&marks.Search{Name: "daily", Query: "tag:standup"}); err != nil {
+		t.Fatal(err.Error())
+	}
+	if err := s.Save(&marks.Search{Name: "uk", Query: "@news tag:uk"}); err != nil {
+		t.Fatal(err.Error())
+	}
+	actual, err := s.Searches()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	expected := []*marks.Search{
+		&marks.Search{Name: "daily", Query: "tag:standup"},
+		&marks.Search{Name: "news", Query: "tag:news OR @daily"},
+		&marks.Search{Name: "uk", Query: "@news tag:uk"},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %v, received %v", expected, actual)
+	}
+}
+
+func TestSaveInvalidSearch(t *testing.T) {
+	s := newTestSearchService(map[string][]byte{"searches.yaml": []byte(testSearchesYaml)})
+	invalid := []*marks.Search{
+		&marks.Search{Name: "bad name", Query: "tag:news"},
+		&marks.Search{Name: "broken", Query: "tag:news AND"},
+		&marks.Search{Name: "missing", Query: "@nothing"},
+		&marks.Search{Name: "daily", Query: "@news"},
+	}
+	for _, search := range invalid {
+		if err := s.Save(search); err == nil {
+			t.Fatalf("expected error saving %v", search)
+		}
+	}
+}
+
+func TestDeleteSearch(t *testing.T) {
+	s := newTestSearchService(map[string][]byte{"searches.yaml": []byte(testSearchesYaml)})
+	if err := s.Delete("daily"); err == nil {
+		t.Fatal("should not delete a search referred to by another")
+	}
+	if err := s.Delete("news"); err != nil {
+		t.Fatal(err.Error())
+	}
+	if err := s.Delete("news"); err != (marks.SearchDoesNotExistError{}) {
+		t.Fatalf("expected SearchDoesNotExistError, received %v", err)
+	}
+}
+
+func TestValidateSearches(t *testing.T) {
+	s := newTestSearchService(map[string][]byte{"searches.yaml": []byte(testSearchesYaml)})
+	if _, err := s.Searches(); err != nil {
+		t.Fatal(err.Error())
+	}
+	s = newTestSearchService(map[string][]byte{"searches.yaml": []byte("- name: loop\n  query: \"@loop\"\n")})
+	if _, err := s.Searches(); err == nil {
+		t.Fatal("expected error")
+	}
+	if _, err := s.resolver(); err == nil {
+		t.Fatal("expected error")
+	}
+	if err := s.Delete("loop"); err != nil {
+		t.Fatal(err.Error())
+	}
+}
+
+func TestSearchMarksWithSavedSearch(t *testing.T) {
+	files := map[string][]byte{"searches.yaml": []byte("- name: uk\n  query: tag:uk\n")}
+	defaultMarks, err := mockReadFile("")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	files["bookmarks.yaml"] = defaultMarks
+	searches := newTestSearchService(files)
+	searches.config.MarksYamlFile = "bookmarks.yaml"
+	s := &markService{searches.config, searches.readerWriter}
+	result, err := s.Search("@uk")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(result) != 1 || result[0].Id != "BBC News" {
+		t.Fatalf("expected BBC News, received %v", result)
+	}
+}
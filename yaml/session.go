@@ -0,0 +1,136 @@
+package yaml
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/tomguerney/marks/marks"
+	"gopkg.in/yaml.v2"
+)
+
+type sessionService struct {
+	config       *marks.Config
+	readerWriter ReaderWriter
+}
+
+func NewSessionService(config *marks.Config, readerWriter ReaderWriter) *sessionService {
+	return &sessionService{config, readerWriter}
+}
+
+func (s *sessionService) Session(name string) (*marks.Session, error) {
+	sessions, err := s.loadSessions()
+	if err != nil {
+		return nil, err
+	}
+	for _, session := range sessions {
+		if strings.EqualFold(session.Name, name) {
+			return session, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *sessionService) Sessions() ([]*marks.Session, error) {
+	return s.loadSessions()
+}
+
+// Save creates the session, or replaces the session with the same name.
+func (s *sessionService) Save(new *marks.Session) error {
+	if !searchNamePattern.MatchString(new.Name) {
+		return errors.New(fmt.Sprintf("\"%v\" is not a valid session name, use letters, numbers, - and _", new.Name))
+	}
+	sessions, err := s.loadSessions()
+	if err != nil {
+		return err
+	}
+	replaced := false
+	for i, session := range sessions {
+		if strings.EqualFold(session.Name, new.Name) {
+			sessions[i] = new
+			replaced = true
+		}
+	}
+	if !replaced {
+		sessions = append(sessions, new)
+	}
+	return s.saveSessions(sessions)
+}
+
+func (s *sessionService) Delete(name string) error {
+	sessions, err := s.loadSessions()
+	if err != nil {
+		return err
+	}
+	remaining := []*marks.Session{}
+	for _, session := range sessions {
+		if !strings.EqualFold(session.Name, name) {
+			remaining = append(remaining, session)
+		}
+	}
+	if len(remaining) == len(sessions) {
+		return marks.SessionDoesNotExistError{}
+	}
+	return s.saveSessions(remaining)
+}
+
+// follow updates the sessions after marks are renamed or deleted. Ids maps
+// each old id, lower cased, to its new id, or to "" if it was deleted.
+func (s *sessionService) follow(ids map[string]string) error {
+	if len(ids) == 0 || s.config.SessionsYamlFile == "" {
+		return nil
+	}
+	sessions, err := s.loadSessions()
+	if err != nil {
+		return err
+	}
+	changed := false
+	for _, session := range sessions {
+		kept := []string{}
+		for _, id := range session.Marks {
+			to, ok := ids[strings.ToLower(id)]
+			if !ok {
+				kept = append(kept, id)
+				continue
+			}
+			changed = true
+			if to != "" {
+				kept = append(kept, to)
+			}
+		}
+		session.Marks = kept
+	}
+	if !changed {
+		return nil
+	}
+	return s.saveSessions(sessions)
+}
+
+func (s *sessionService) loadSessions() ([]*marks.Session, error) {
+	sessionsYaml, err := s.readerWriter.ReadFile(s.yamlPath())
+	if os.IsNotExist(err) {
+		return []*marks.Session{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	sessions := []*marks.Session{}
+	if err := yaml.Unmarshal(sessionsYaml, &sessions); err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+func (s *sessionService) saveSessions(sessions []*marks.Session) error {
+	sessionsYaml, err := yaml.Marshal(sessions)
+	if err != nil {
+		return err
+	}
+	return s.readerWriter.WriteFile(s.yamlPath(), sessionsYaml, s.config.MarksYamlFileMode)
+}
+
+func (s *sessionService) yamlPath() string {
+	return path.Join(s.config.ContentPath, s.config.SessionsYamlFile)
+}
@@ -0,0 +1,97 @@
+package yaml
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/tomguerney/marks/marks"
+	"github.com/tomguerney/marks/mocks"
+)
+
+const testSessionsYaml = `- name: morning
+  marks:
+  - Jira
+  - Calendar
+  - Slack
+`
+
+func newTestSessionService(files map[string][]byte) *sessionService {
+	config := mocks.NewConfig()
+	config.SessionsYamlFile = "sessions.yaml"
+	return &sessionService{
+		config,
+		&mockReaderWriter{
+			func(name string) ([]byte, error) {
+				data, ok := files[name]
+				if !ok {
+					return nil, os.ErrNotExist
+				}
+				return data, nil
+			},
+			func(name string, data []byte, perm uint32) error {
+				files[name] = data
+				return nil
+			},
+		},
+	}
+}
+
+func TestSession(t *testing.T) {
+	s := newTestSessionService(map[string][]byte{"sessions.yaml": []byte(testSessionsYaml)})
+	actual, err := s.Session("Morning")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	expected := &marks.Session{Name: "morning", Marks: []string{"Jira", "Calendar", "Slack"}}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %v, received %v", expected, actual)
+	}
+}
+
+func TestSessionsMissingFile(t *testing.T) {
+	s := newTestSessionService(map[string][]byte{})
+	actual, err := s.Sessions()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(actual) != 0 {
+		t.Fatalf("expected no sessions, received %v", actual)
+	}
+}
+
+func TestSaveSessionReplaces(t *testing.T) {
+	files := map[string][]byte{"sessions.yaml": []byte(testSessionsYaml)}
+	s := newTestSessionService(files)
+	if err := s.Save(&marks.Session{Name: "evening", Marks: []string{"News"}}); err != nil {
+		t.Fatal(err.Error())
+	}
+	if err := s.Save(&marks.Session{Name: "morning", Marks: []string{"Jira"}}); err != nil {
+		t.Fatal(err.Error())
+	}
+	expected := "- name: morning\n  marks:\n  - Jira\n- name: evening\n  marks:\n  - News\n"
+	if string(files["sessions.yaml"]) != expected {
+		t.Fatalf("expected %q, received %q", expected, string(files["sessions.yaml"]))
+	}
+}
+
+func TestSaveSessionInvalidName(t *testing.T) {
+	s := newTestSessionService(map[string][]byte{})
+	if err := s.Save(&marks.Session{Name: "my session"}); err == nil {
+		t.Fatal("should return error")
+	}
+}
+
+func TestDeleteSession(t *testing.T) {
+	files := map[string][]byte{"sessions.yaml": []byte(testSessionsYaml)}
+	s := newTestSessionService(files)
+	if err := s.Delete("morning"); err != nil {
+		t.Fatal(err.Error())
+	}
+	if string(files["sessions.yaml"]) != "[]\n" {
+		t.Fatalf("unexpected sessions %q", string(files["sessions.yaml"]))
+	}
+	if _, ok := s.Delete("morning").(marks.SessionDoesNotExistError); !ok {
+		t.Fatal("expected session does not exist error")
+	}
+}
@@ -3,8 +3,11 @@ package yaml
 import (
 	"path"
 	"strings"
+	"time"
 
+	"github.com/tomguerney/marks/fuzzy"
 	"github.com/tomguerney/marks/marks"
+	"github.com/tomguerney/marks/query"
 	"gopkg.in/yaml.v2"
 )
 
@@ -59,19 +62,141 @@ func (s *markService) Create(m *marks.Mark) error {
 	return nil
 }
 
+func (s *markService) CreateAll(new []*marks.Mark) error {
+	existing, err := s.loadMarks()
+	if err != nil {
+		return err
+	}
+	ids := map[string]bool{}
+	for _, mark := range existing {
+		ids[strings.ToLower(mark.Id)] = true
+	}
+	for _, mark := range new {
+		id := strings.ToLower(mark.Id)
+		if ids[id] {
+			return marks.MarkAlreadyExistsError{}
+		}
+		ids[id] = true
+	}
+	return s.saveMarks(append(existing, new...))
+}
+
 func (s *markService) Update(id string, new *marks.Mark) error {
 	updateFn := func(i int, marks []*marks.Mark) []*marks.Mark {
 		marks[i] = new
 		return marks
 	}
-	return s.modify(id, updateFn)
+	if err := s.modify(id, updateFn); err != nil {
+		return err
+	}
+	return s.followIds(renamed(map[string]*marks.Mark{id: new}), nil)
+}
+
+// UpdateAll replaces each mark whose id is a key of updates with its
+// value, saving them together so that either all are updated or none.
+func (s *markService) UpdateAll(updates map[string]*marks.Mark) error {
+	loaded, err := s.loadMarks()
+	if err != nil {
+		return err
+	}
+	found := 0
+	for i, mark := range loaded {
+		for id, new := range updates {
+			if strings.ToLower(mark.Id) == strings.ToLower(id) {
+				loaded[i] = new
+				found++
+				break
+			}
+		}
+	}
+	if found != len(updates) {
+		return marks.MarkDoesNotExistError{}
+	}
+	if err := s.saveMarks(loaded); err != nil {
+		return err
+	}
+	return s.followIds(renamed(updates), nil)
 }
 
 func (s *markService) Delete(id string) error {
 	deleteFn := func(i int, marks []*marks.Mark) []*marks.Mark {
 		return append(marks[:i], marks[i+1:]...)
 	}
-	return s.modify(id, deleteFn)
+	if err := s.modify(id, deleteFn); err != nil {
+		return err
+	}
+	return s.followIds(nil, []string{id})
+}
+
+// followIds rewrites the sessions that refer to renamed or deleted marks.
+func (s *markService) followIds(renames map[string]string, deletes []string) error {
+	ids := map[string]string{}
+	for from, to := range renames {
+		ids[strings.ToLower(from)] = to
+	}
+	for _, id := range deletes {
+		ids[strings.ToLower(id)] = ""
+	}
+	return NewSessionService(s.config, s.readerWriter).follow(ids)
+}
+
+// renamed returns the old and new ids of the updates that change an id.
+func renamed(updates map[string]*marks.Mark) map[string]string {
+	renames := map[string]string{}
+	for id, m := range updates {
+		if m.Id != id {
+			renames[id] = m.Id
+		}
+	}
+	return renames
+}
+
+// Apply makes every change in c and saves once. Nothing is saved if a
+// mark to update or delete is missing, or if the result would hold two
+// marks with the same id.
+func (s *markService) Apply(c *marks.Changeset) error {
+	loaded, err := s.loadMarks()
+	if err != nil {
+		return err
+	}
+	deletes := map[string]bool{}
+	for _, id := range c.Deletes {
+		deletes[strings.ToLower(id)] = true
+	}
+	updates := map[string]*marks.Mark{}
+	for id, m := range c.Updates {
+		updates[strings.ToLower(id)] = m
+	}
+	found := 0
+	applied := []*marks.Mark{}
+	for _, mark := range loaded {
+		id := strings.ToLower(mark.Id)
+		if deletes[id] {
+			found++
+			continue
+		}
+		if updated, ok := updates[id]; ok {
+			found++
+			mark = updated
+		}
+		applied = append(applied, mark)
+	}
+	if found != len(deletes)+len(updates) {
+		return marks.MarkDoesNotExistError{}
+	}
+	applied = append(applied, c.Creates...)
+	ids := map[string]bool{}
+	for _, mark := range applied {
+		id := strings.ToLower(mark.Id)
+		if ids[id] {
+			return marks.MarkAlreadyExistsError{}
+		}
+		ids[id] = true
+	}
+	if err := s.saveMarks(applied); err != nil {
+		return err
+	}
+	return s.followIds(renamed(c.Updates), c.Deletes)
 }
 
 func (s *markService) Contains(id string) (bool, error) {
@@ -109,46 +234,40 @@ func (s *markService) modify(id string, modifyFn func(int, []*marks.Mark) []*mar
 }
 
 func (s *markService) Filter(id, url string, tags []string) ([]*marks.Mark, error) {
-	filtered, err := s.loadMarks()
+	loaded, err := s.loadMarks()
 	if err != nil {
 		return nil, err
 	}
-	filtered = filterId(filtered, id)
-	filtered = filterUrl(filtered, url)
-	filtered = filterTags(filtered, tags)
-	return filtered, nil
-}
-
-func filterId(unfiltered []*marks.Mark, id string) (filtered []*marks.Mark) {
-	if id == "" {
-		return unfiltered
-	}
-	for _, mark := range unfiltered {
-		if strings.Contains(strings.ToLower(mark.Id), strings.ToLower(id)) {
-			filtered = append(filtered, mark)
-		}
+	if s.config.Fuzzy {
+		return fuzzy.Filter(loaded, id, url, tags, s.config.TagSeparator, marks.NewTagNormalizer(s.config)), nil
 	}
-	return filtered
+	return s.match(loaded, query.FromFilter(id, url, tags)), nil
 }
 
-func filterUrl(unfiltered []*marks.Mark, url string) (filtered []*marks.Mark) {
-	if url == "" {
-		return unfiltered
+func (s *markService) Search(expr string) ([]*marks.Mark, error) {
+	resolve, err := NewSearchService(s.config, s.readerWriter).resolver()
+	if err != nil {
+		return nil, err
 	}
-	for _, mark := range unfiltered {
-		if strings.Contains(strings.ToLower(mark.Url), strings.ToLower(url)) {
-			filtered = append(filtered, mark)
-		}
+	node, err := query.Parse(expr, time.Now(), resolve)
+	if err != nil {
+		return nil, err
 	}
-	return filtered
+	loaded, err := s.loadMarks()
+	if err != nil {
+		return nil, err
+	}
+	return s.match(loaded, node), nil
 }
 
-func filterTags(unfiltered []*marks.Mark, tags []string) (filtered []*marks.Mark) {
-	if len(tags) == 0 {
-		return unfiltered
+func (s *markService) match(unfiltered []*marks.Mark, node query.Node) (filtered []*marks.Mark) {
+	env := &query.Env{
+		Fuzzy:      s.config.Fuzzy,
+		Separator:  s.config.TagSeparator,
+		Normalizer: marks.NewTagNormalizer(s.config),
 	}
 	for _, mark := range unfiltered {
-		if mark.ContainsAllTags(tags) {
+		if node.Match(mark, env) {
 			filtered = append(filtered, mark)
 		}
 	}
@@ -3,6 +3,7 @@ package yaml
 import (
 	"errors"
 	"io/ioutil"
+	"path"
 	"path/filepath"
 	"reflect"
 	"testing"
@@ -166,6 +167,108 @@ func TestUpdateNonExistentMark(t *testing.T) {
 	}
 }
 
+func TestUpdateAllMarks(t *testing.T) {
+	updates := map[string]*marks.Mark{
+		"google":   &marks.Mark{Id: "Google", Url: "https://www.google.com", Tags: []string{"engine"}},
+		"BBC News": &marks.Mark{Id: "BBC", Url: "https://www.bbc.com/news", Tags: []string{"uk"}},
+	}
+	writeCalled := 0
+	writeFunc := func(s string, bytes []byte, u uint32) error {
+		writeCalled++
+		actual := []*marks.Mark{}
+		if err := yaml.Unmarshal(bytes, &actual); err != nil {
+			t.Fatal(err.Error())
+		}
+		if len(actual) != 5 {
+			t.Fatalf("expected 5 marks, received %v", len(actual))
+		}
+		if !reflect.DeepEqual(actual[0], updates["google"]) || !reflect.DeepEqual(actual[3], updates["BBC News"]) {
+			t.Fatalf("expected updated marks, received %v and %v", actual[0], actual[3])
+		}
+		return nil
+	}
+	s := newTestMarkService()
+	s.readerWriter.(*mockReaderWriter).WriteFileFn = writeFunc
+	if err := s.UpdateAll(updates); err != nil {
+		t.Fatal(err.Error())
+	}
+	if writeCalled != 1 {
+		t.Fatalf("expected one write, received %v", writeCalled)
+	}
+}
+
+func TestUpdateAllWithNonExistentMark(t *testing.T) {
+	updates := map[string]*marks.Mark{
+		"Google":     &marks.Mark{Id: "Google"},
+		"not a mark": &marks.Mark{Id: "not a mark"},
+	}
+	s := newTestMarkService()
+	s.readerWriter.(*mockReaderWriter).WriteFileFn = func(string, []byte, uint32) error {
+		t.Fatal("nothing should be written")
+		return nil
+	}
+	err := s.UpdateAll(updates)
+	if _, ok := err.(marks.MarkDoesNotExistError); !ok {
+		t.Fatalf("expected MarkDoesNotExistError, received %v", err)
+	}
+}
+
+func TestApplyChangeset(t *testing.T) {
+	changeset := &marks.Changeset{
+		Creates: []*marks.Mark{&marks.Mark{Id: "Guardian", Url: "https://www.theguardian.com"}},
+		Updates: map[string]*marks.Mark{
+			"bbc news": &marks.Mark{Id: "BBC", Url: "https://www.bbc.com/news"},
+		},
+		Deletes: []string{"Google"},
+	}
+	writeCalled := 0
+	s := newTestMarkService()
+	s.readerWriter.(*mockReaderWriter).WriteFileFn = func(s string, bytes []byte, u uint32) error {
+		writeCalled++
+		actual := []*marks.Mark{}
+		if err := yaml.Unmarshal(bytes, &actual); err != nil {
+			t.Fatal(err.Error())
+		}
+		ids := []string{}
+		for _, m := range actual {
+			ids = append(ids, m.Id)
+		}
+		expected := []string{"Abc News", "Little Bird Electronics How-to Guides & Tutorials", "BBC", "Electronics Weekly", "Guardian"}
+		if !reflect.DeepEqual(ids, expected) {
+			t.Fatalf("expected %v, received %v", expected, ids)
+		}
+		return nil
+	}
+	if err := s.Apply(changeset); err != nil {
+		t.Fatal(err.Error())
+	}
+	if writeCalled != 1 {
+		t.Fatalf("expected one write, received %v", writeCalled)
+	}
+}
+
+func TestApplyChangesetWithClashingIds(t *testing.T) {
+	changeset := &marks.Changeset{
+		Updates: map[string]*marks.Mark{"BBC News": &marks.Mark{Id: "google"}},
+	}
+	s := newTestMarkService()
+	s.readerWriter.(*mockReaderWriter).WriteFileFn = func(string, []byte, uint32) error {
+		t.Fatal("should not write")
+		return nil
+	}
+	if _, ok := s.Apply(changeset).(marks.MarkAlreadyExistsError); !ok {
+		t.Fatal("expected MarkAlreadyExistsError")
+	}
+}
+
+func TestApplyChangesetWithNonExistentMark(t *testing.T) {
+	changeset := &marks.Changeset{Deletes: []string{"Not a mark"}}
+	s := newTestMarkService()
+	if _, ok := s.Apply(changeset).(marks.MarkDoesNotExistError); !ok {
+		t.Fatal("expected MarkDoesNotExistError")
+	}
+}
+
 func TestDeleteMark(t *testing.T) {
 	deletedId := "Google"
 	writeFunc := func(s string, bytes []byte, u uint32) error {
@@ -469,3 +572,142 @@ func TestFilterAllMarksByNoInput(t *testing.T) {
 		t.Errorf("expected 5 marks, received %v", len(result))
 	}
 }
+
+func TestFilterFuzzy(t *testing.T) {
+	s := newTestMarkService()
+	s.config.Fuzzy = true
+	result, err := s.Filter("abcnews", "", []string{"curent affairs"})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(result) != 1 || result[0].Id != "Abc News" {
+		t.Errorf("expected Abc News, received %v", result)
+	}
+}
+
+func TestFilterFuzzyRanksByScore(t *testing.T) {
+	s := newTestMarkService()
+	s.config.Fuzzy = true
+	result, err := s.Filter("elec", "", []string{})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	ids := []string{}
+	for _, m := range result {
+		ids = append(ids, m.Id)
+	}
+	expected := []string{"Electronics Weekly", "Little Bird Electronics How-to Guides & Tutorials"}
+	if !reflect.DeepEqual(ids, expected) {
+		t.Errorf("expected %v, received %v", expected, ids)
+	}
+}
+
+func TestCreateAllMarks(t *testing.T) {
+	new := []*marks.Mark{
+		&marks.Mark{Id: "Github", Url: "https://github.com/"},
+		&marks.Mark{Id: "Gitlab", Url: "https://gitlab.com/"},
+	}
+	writeCalls := 0
+	writeFunc := func(s string, bytes []byte, u uint32) error {
+		writeCalls++
+		marks := []*marks.Mark{}
+		if err := yaml.Unmarshal(bytes, &marks); err != nil {
+			t.Fatal(err.Error())
+		}
+		if len(marks) != 7 {
+			t.Errorf("expected 7 marks, received %v", len(marks))
+		}
+		return nil
+	}
+	s := newTestMarkService()
+	s.readerWriter.(*mockReaderWriter).WriteFileFn = writeFunc
+	if err := s.CreateAll(new); err != nil {
+		t.Fatal(err.Error())
+	}
+	if writeCalls != 1 {
+		t.Fatalf("expected 1 write, received %v", writeCalls)
+	}
+}
+
+func TestCreateAllWithExistingMark(t *testing.T) {
+	new := []*marks.Mark{
+		&marks.Mark{Id: "Github", Url: "https://github.com/"},
+		&marks.Mark{Id: "google", Url: "https://www.google.com/"},
+	}
+	writeFunc := func(string, []byte, uint32) error {
+		t.Fatal("write should not be called")
+		return nil
+	}
+	s := newTestMarkService()
+	s.readerWriter.(*mockReaderWriter).WriteFileFn = writeFunc
+	err := s.CreateAll(new)
+	if _, ok := err.(marks.MarkAlreadyExistsError); !ok {
+		t.Fatalf("expected MarkAlreadyExistsError, received %T", err)
+	}
+}
+
+func TestFilterMarksByParentTag(t *testing.T) {
+	s := newTestMarkService()
+	s.config.TagSeparator = "/"
+	s.readerWriter.(*mockReaderWriter).ReadFileFn = func(string) ([]byte, error) {
+		return []byte("- id: ci\n  tags: [work/projectx/ci]\n- id: admin\n  tags: [work/admin]\n- id: workshop\n  tags: [workshop]\n"), nil
+	}
+	result, err := s.Filter("", "", []string{"work"})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(result) != 2 {
+		t.Errorf("expected 2 marks, received %v", len(result))
+	}
+}
+
+func newTestMarkServiceWithSessions(sessionsYaml string) (*markService, map[string][]byte) {
+	s := newTestMarkService()
+	s.config.SessionsYamlFile = "sessions.yaml"
+	sessionsPath := path.Join(s.config.ContentPath, "sessions.yaml")
+	files := map[string][]byte{sessionsPath: []byte(sessionsYaml)}
+	s.readerWriter.(*mockReaderWriter).ReadFileFn = func(name string) ([]byte, error) {
+		if name == sessionsPath {
+			return files[name], nil
+		}
+		return mockReadFile(name)
+	}
+	s.readerWriter.(*mockReaderWriter).WriteFileFn = func(name string, data []byte, perm uint32) error {
+		files[name] = data
+		return nil
+	}
+	return s, files
+}
+
+func TestRenameMarkUpdatesSessions(t *testing.T) {
+	s, files := newTestMarkServiceWithSessions("- name: morning\n  marks:\n  - google\n  - Abc News\n")
+	if err := s.Update("Google", &marks.Mark{Id: "Search", Url: "https://www.google.com"}); err != nil {
+		t.Fatal(err.Error())
+	}
+	expected := "- name: morning\n  marks:\n  - Search\n  - Abc News\n"
+	if actual := string(files["sessions.yaml"]); actual != expected {
+		t.Fatalf("expected %q, received %q", expected, actual)
+	}
+}
+
+func TestDeleteMarkUpdatesSessions(t *testing.T) {
+	s, files := newTestMarkServiceWithSessions("- name: morning\n  marks:\n  - Google\n  - Abc News\n")
+	if err := s.Apply(&marks.Changeset{Deletes: []string{"google"}}); err != nil {
+		t.Fatal(err.Error())
+	}
+	expected := "- name: morning\n  marks:\n  - Abc News\n"
+	if actual := string(files["sessions.yaml"]); actual != expected {
+		t.Fatalf("expected %q, received %q", expected, actual)
+	}
+}
+
+func TestUpdateMarkLeavesSessionsAlone(t *testing.T) {
+	sessionsYaml := "- {name: morning, marks: [Google]}\n"
+	s, files := newTestMarkServiceWithSessions(sessionsYaml)
+	if err := s.Update("Google", &marks.Mark{Id: "Google", Url: "https://google.com"}); err != nil {
+		t.Fatal(err.Error())
+	}
+	if string(files["sessions.yaml"]) != sessionsYaml {
+		t.Fatal("sessions should not be saved when no id changes")
+	}
+}